@@ -0,0 +1,265 @@
+package kubernetes
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// translateResourceAPIErrors wraps Create and Update of the given resource so
+// validation errors returned by the API server refer to attribute paths of
+// the resource instead of Kubernetes field paths
+func translateResourceAPIErrors(r *schema.Resource) {
+	if create := r.Create; create != nil {
+		r.Create = func(d *schema.ResourceData, meta interface{}) error {
+			return translateWrappedError(create(d, meta), func(err error) error {
+				return translateAPIError(err, r.Schema)
+			})
+		}
+	}
+	if update := r.Update; update != nil {
+		r.Update = func(d *schema.ResourceData, meta interface{}) error {
+			return translateWrappedError(update(d, meta), func(err error) error {
+				return translateUpdateError(err, d, meta, r.Schema)
+			})
+		}
+	}
+}
+
+// apiCallError adds the context of the failed call to an error returned by
+// the API server. Unlike fmt.Errorf it keeps the status error, so it can
+// still be translated by translateResourceAPIErrors.
+type apiCallError struct {
+	context string
+	err     error
+}
+
+func (e *apiCallError) Error() string {
+	return fmt.Sprintf("%s: %s", e.context, e.err)
+}
+
+// wrapAPIError adds context to an error returned by the API server, the
+// message reads like fmt.Errorf("<context>: %s", err)
+func wrapAPIError(err error, context string) error {
+	return &apiCallError{context: context, err: err}
+}
+
+// translateWrappedError applies translate to the API error wrapped by
+// wrapAPIError, or to err itself when it isn't wrapped
+func translateWrappedError(err error, translate func(error) error) error {
+	if e, ok := err.(*apiCallError); ok {
+		return fmt.Errorf("%s: %s", e.context, translate(e.err))
+	}
+	return translate(err)
+}
+
+// translateUpdateError translates the errors returned by the API server on
+// update of a resource with the given schema
+func translateUpdateError(err error, d *schema.ResourceData, meta interface{}, s map[string]*schema.Schema) error {
+	err = translateApplyConflictError(err, s)
+	err = translateConflictError(err, d, meta)
+	return translateAPIError(err, s)
+}
+
+// translateConflictError explains updates rejected because the resource has
+// been modified since the last refresh, either by the resource version sent
+// with the object (409) or by the test operation of a patch
+func translateConflictError(err error, d *schema.ResourceData, meta interface{}) error {
+	statusErr, ok := err.(*errors.StatusError)
+	if !ok {
+		return err
+	}
+
+	// Only the test operation added by withResourceVersionTest, other
+	// patches which couldn't be applied are reported as they are
+	failedTest := meta.(*kubernetesProvider).optimisticConcurrency && isFailedResourceVersionTest(statusErr)
+	if !errors.IsConflict(statusErr) && !failedTest {
+		return err
+	}
+
+	return fmt.Errorf("%q has been modified outside of Terraform since it was last read (resource version %q). "+
+		"Run `terraform plan` again to refresh the state and review the changes before applying: %s",
+		d.Id(), d.Get("metadata.0.resource_version"), err)
+}
+
+// isFailedResourceVersionTest reports whether a patch was rejected by the
+// test operation of the resource version. The API server returns patches
+// which can't be applied as Invalid (422) with the error of the JSON patch
+// library, e.g. "testing value /metadata/resourceVersion failed: test failed"
+func isFailedResourceVersionTest(err *errors.StatusError) bool {
+	status := err.ErrStatus
+	if status.Code != 422 || status.Reason != metav1.StatusReasonInvalid {
+		return false
+	}
+	messages := []string{status.Message}
+	if status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			messages = append(messages, cause.Message)
+		}
+	}
+	for _, m := range messages {
+		// Older versions of the library capitalize the message
+		if strings.HasPrefix(strings.ToLower(m), "testing value /metadata/resourceversion failed") {
+			return true
+		}
+	}
+	return false
+}
+
+// translateAPIError turns an Invalid (422) status error into an error listing
+// each invalid field with its reason, e.g.
+// spec.template.spec.containers[0].ports[1].containerPort becomes
+// spec.0.template.0.spec.0.container.0.port.1.container_port
+// Any other error is returned unchanged.
+func translateAPIError(err error, s map[string]*schema.Schema) error {
+	statusErr, ok := err.(*errors.StatusError)
+	if !ok {
+		return err
+	}
+	details := statusErr.ErrStatus.Details
+	if statusErr.ErrStatus.Code != 422 || details == nil || len(details.Causes) == 0 {
+		return err
+	}
+
+	var result *multierror.Error
+	for _, cause := range details.Causes {
+		if cause.Field == "" {
+			result = multierror.Append(result, stderrors.New(cause.Message))
+			continue
+		}
+		result = multierror.Append(result, fmt.Errorf("%s: %s", translateFieldPath(cause.Field, s), cause.Message))
+	}
+
+	result.ErrorFormat = func(es []error) string {
+		lines := make([]string, len(es))
+		for i, e := range es {
+			lines[i] = "* " + e.Error()
+		}
+		return fmt.Sprintf("%s %q is invalid:\n\n%s", details.Kind, details.Name, strings.Join(lines, "\n"))
+	}
+
+	return result
+}
+
+// translateFieldPath converts a Kubernetes field path into the path of the
+// matching attribute in the given schema. Parts without a matching attribute
+// are converted to snake case as-is.
+func translateFieldPath(path string, s map[string]*schema.Schema) string {
+	out := make([]string, 0)
+	current := s
+	for _, segment := range splitFieldPath(path) {
+		name, index, hasIndex := parseFieldPathSegment(segment)
+
+		key, sch := lookupFieldPathAttribute(name, current)
+		out = append(out, key)
+		current = nil
+		if sch == nil {
+			if hasIndex {
+				out = append(out, index)
+			}
+			continue
+		}
+
+		switch sch.Type {
+		case schema.TypeMap:
+			if hasIndex {
+				out = append(out, index)
+			}
+		case schema.TypeList, schema.TypeSet:
+			if hasIndex {
+				out = append(out, index)
+			} else if sch.MaxItems == 1 {
+				// Objects are lists of a single block
+				out = append(out, "0")
+			}
+			if elem, ok := sch.Elem.(*schema.Resource); ok {
+				current = elem.Schema
+			}
+		}
+	}
+	return strings.Join(out, ".")
+}
+
+// lookupFieldPathAttribute finds the attribute for a Kubernetes field name,
+// list fields are usually singular in this provider (containers -> container)
+func lookupFieldPathAttribute(name string, s map[string]*schema.Schema) (string, *schema.Schema) {
+	key := toSnakeCase(name)
+	if s == nil {
+		return key, nil
+	}
+
+	candidates := []string{key}
+	if key == "selector" {
+		// Workloads keep set-based selectors in label_selector, their
+		// selector is the deprecated map of labels
+		candidates = []string{"label_selector", key}
+	}
+	switch {
+	case strings.HasSuffix(key, "ies"):
+		candidates = append(candidates, strings.TrimSuffix(key, "ies")+"y")
+	case strings.HasSuffix(key, "es"):
+		candidates = append(candidates, strings.TrimSuffix(key, "s"), strings.TrimSuffix(key, "es"))
+	case strings.HasSuffix(key, "s"):
+		candidates = append(candidates, strings.TrimSuffix(key, "s"))
+	}
+	for _, c := range candidates {
+		if sch, ok := s[c]; ok {
+			return c, sch
+		}
+	}
+	return key, nil
+}
+
+// splitFieldPath splits a field path on dots outside of brackets,
+// map keys may contain dots (e.g. metadata.labels[app.kubernetes.io/name])
+func splitFieldPath(path string) []string {
+	segments := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, r := range path {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				segments = append(segments, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, path[start:])
+}
+
+func parseFieldPathSegment(segment string) (string, string, bool) {
+	i := strings.Index(segment, "[")
+	if i < 0 || !strings.HasSuffix(segment, "]") {
+		return segment, "", false
+	}
+	return segment[:i], segment[i+1 : len(segment)-1], true
+}
+
+// toSnakeCase converts camel case field names, keeping acronyms together
+// (containerPort -> container_port, podIP -> pod_ip, hostIPC -> host_ipc)
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	tfschema "github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestTranslateFieldPath(t *testing.T) {
+	deployment := resourceKubernetesDeployment().Schema
+	service := resourceKubernetesService().Schema
+
+	cases := []struct {
+		Path     string
+		Expected string
+	}{
+		{"metadata.name", "metadata.0.name"},
+		{"metadata.labels[app.kubernetes.io/name]", "metadata.0.labels.app.kubernetes.io/name"},
+		{"spec.replicas", "spec.0.replicas"},
+		{"spec.template.spec.containers[0].ports[1].containerPort", "spec.0.template.0.spec.0.container.0.port.1.container_port"},
+		{"spec.template.spec.initContainers[1].volumeMounts[0].mountPath", "spec.0.template.0.spec.0.init_container.1.volume_mount.0.mount_path"},
+		{"spec.template.spec.containers[0].resources.limits[cpu]", "spec.0.template.0.spec.0.container.0.resources.0.limits.cpu"},
+		{"spec.template.spec.volumes[2].name", "spec.0.template.0.spec.0.volume.2.name"},
+		{"spec.selector.matchLabels", "spec.0.label_selector.0.match_labels"},
+		{"spec.unknownField[3].someValue", "spec.0.unknown_field.3.some_value"},
+	}
+	for _, tc := range cases {
+		out := translateFieldPath(tc.Path, deployment)
+		if out != tc.Expected {
+			t.Fatalf("%s: expected %q, got %q", tc.Path, tc.Expected, out)
+		}
+	}
+
+	out := translateFieldPath("spec.ports[0].nodePort", service)
+	if out != "spec.0.port.0.node_port" {
+		t.Fatalf("expected service port path, got %q", out)
+	}
+}
+
+func TestTranslateAPIError(t *testing.T) {
+	s := resourceKubernetesDeployment().Schema
+
+	invalid := errors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "foo", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "template", "spec", "containers").Index(0).Child("ports").Index(1).Child("containerPort"), 0, "must be between 1 and 65535, inclusive"),
+		field.Required(field.NewPath("spec", "template", "spec", "containers").Index(0).Child("image"), ""),
+	})
+
+	expected := `Deployment "foo" is invalid:
+
+* spec.0.template.0.spec.0.container.0.port.1.container_port: Invalid value: 0: must be between 1 and 65535, inclusive
+* spec.0.template.0.spec.0.container.0.image: Required value`
+	if err := translateAPIError(invalid, s); err.Error() != expected {
+		t.Fatalf("expected:\n%s\n\ngot:\n%s", expected, err)
+	}
+
+	wrapped := fmt.Errorf("Failed to update deployment: %s", invalid)
+	if err := translateAPIError(wrapped, s); err != wrapped {
+		t.Fatalf("expected errors wrapped by the caller to be returned as-is, got: %s", err)
+	}
+
+	translate := func(err error) error { return translateAPIError(err, s) }
+	err := translateWrappedError(wrapAPIError(invalid, "Failed to update deployment"), translate)
+	if err.Error() != "Failed to update deployment: "+expected {
+		t.Fatalf("expected errors wrapped with wrapAPIError to be translated, got: %s", err)
+	}
+
+	notFound := errors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo")
+	if err := translateAPIError(notFound, s); err != notFound {
+		t.Fatalf("expected other errors to be returned as-is, got: %s", err)
+	}
+
+	if err := translateAPIError(nil, s); err != nil {
+		t.Fatalf("expected nil, got: %s", err)
+	}
+}
+
+func TestTranslateConflictError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "configmaps"}
+	d := tfschema.TestResourceDataRaw(t, resourceKubernetesConfigMap().Schema, map[string]interface{}{})
+	d.SetId("default/foo")
+
+	meta := &kubernetesProvider{optimisticConcurrency: true}
+
+	conflict := errors.NewConflict(gr, "foo", fmt.Errorf("the object has been modified"))
+	if err := translateConflictError(conflict, d, meta); err == conflict {
+		t.Fatal("Expected conflicts to be explained")
+	}
+
+	failedTest := errors.NewGenericServerResponse(422, "patch", gr, "foo", "testing value /metadata/resourceVersion failed", 0, true)
+	if err := translateConflictError(failedTest, d, meta); err == failedTest {
+		t.Fatal("Expected failed resource version tests to be explained")
+	}
+
+	if err := translateConflictError(failedTest, d, &kubernetesProvider{}); err != failedTest {
+		t.Fatalf("Expected patches failing without optimistic concurrency to be returned as-is, got: %s", err)
+	}
+
+	failedPatch := errors.NewGenericServerResponse(422, "patch", gr, "foo", "", 0, false)
+	if err := translateConflictError(failedPatch, d, meta); err != failedPatch {
+		t.Fatalf("Expected patches failing without a reason to be returned as-is, got: %s", err)
+	}
+
+	otherTest := errors.NewGenericServerResponse(422, "patch", gr, "foo", "testing value /data/test failed: test failed", 0, true)
+	if err := translateConflictError(otherTest, d, meta); err != otherTest {
+		t.Fatalf("Expected failed tests of other paths to be returned as-is, got: %s", err)
+	}
+
+	missingTestPath := errors.NewGenericServerResponse(422, "patch", gr, "foo", "doc is missing path: /data/test/metadata/resourceVersion", 0, true)
+	if err := translateConflictError(missingTestPath, d, meta); err != missingTestPath {
+		t.Fatalf("Expected patches of missing paths mentioning the resource version to be returned as-is, got: %s", err)
+	}
+
+	notInvalid := errors.NewGenericServerResponse(400, "patch", gr, "foo", "testing value /metadata/resourceVersion failed", 0, true)
+	if err := translateConflictError(notInvalid, d, meta); err != notInvalid {
+		t.Fatalf("Expected errors other than Invalid to be returned as-is, got: %s", err)
+	}
+
+	missingPath := errors.NewGenericServerResponse(422, "patch", gr, "foo", "Unable to remove nonexistent key: foo: missing value", 0, true)
+	if err := translateConflictError(missingPath, d, meta); err != missingPath {
+		t.Fatalf("Expected patches of missing paths to be returned as-is, got: %s", err)
+	}
+
+	invalid := errors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "foo", field.ErrorList{
+		field.Required(field.NewPath("metadata", "name"), ""),
+	})
+	if err := translateConflictError(invalid, d, meta); err != invalid {
+		t.Fatalf("Expected other errors to be returned as-is, got: %s", err)
+	}
+}
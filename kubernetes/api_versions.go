@@ -22,6 +22,7 @@ const (
 	batchV1beta1
 	batchV2alpha1
 	extensionsV1beta1
+	networkingV1beta1
 )
 
 func (g APIGroup) String() string {
@@ -38,6 +39,8 @@ func (g APIGroup) String() string {
 		return "batch/v1beta1"
 	case batchV2alpha1:
 		return "batch/v2alpha1"
+	case networkingV1beta1:
+		return "networking.k8s.io/v1beta1"
 	default:
 		return "none"
 	}
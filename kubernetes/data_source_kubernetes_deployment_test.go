@@ -40,6 +40,9 @@ func TestAccKubernetesDataSourceDeployment_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.0.template.0.spec.0.container.#", "1"),
 					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
 					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "status.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment.test", "status.0.replicas", "1"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_deployment.test", "status.0.observed_generation"),
 				),
 			},
 		},
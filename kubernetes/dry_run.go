@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+// dryRunRequest describes the object sent to the API server by dryRunDiff
+type dryRunRequest struct {
+	Client   restclient.Interface
+	Resource string
+	// Object is sent as-is when the resource is being created
+	Object interface{}
+	// Spec replaces the spec of an existing object, updates aren't
+	// checked when nil (e.g. the resource patches individual fields)
+	Spec interface{}
+	// OldSpec is the spec in state, when set only the differences
+	// to Spec are patched like the update does
+	OldSpec interface{}
+}
+
+// dryRunDiff sends the planned object to the API server with dryRun=All when
+// enabled on the provider, so errors from admission controllers, quotas or
+// defaults show up during plan instead of in the middle of an apply.
+// Servers without dry-run support, plans with values not known until apply
+// and creations depending on objects not created yet are skipped, the reason
+// is logged.
+func dryRunDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema, build func() (*dryRunRequest, error)) error {
+	kp := meta.(*kubernetesProvider)
+	if !kp.serverDryRun {
+		return nil
+	}
+	if !diff.HasChange("metadata") && !diff.HasChange("spec") {
+		return nil
+	}
+	if key, ok := diffUnknownValue(diff, s, "metadata.", "spec."); ok {
+		log.Printf("[INFO] Skipping server-side dry run of %q, %s is not known until apply", diff.Id(), key)
+		return nil
+	}
+
+	supported, err := kp.serverSupportsDryRun()
+	if err != nil {
+		log.Printf("[WARN] Skipping server-side dry run, could not determine server version: %s", err)
+		return nil
+	}
+	if !supported {
+		log.Printf("[INFO] Skipping server-side dry run of %q, the server does not support it", diff.Id())
+		return nil
+	}
+
+	req, err := build()
+	if err != nil {
+		log.Printf("[DEBUG] Skipping server-side dry run: %s", err)
+		return nil
+	}
+
+	if diff.Id() == "" {
+		metadata := expandMetadata(diff.Get("metadata").([]interface{}))
+		namespace := metadata.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		log.Printf("[DEBUG] Dry running creation of %s %q", req.Resource, metadata.Name)
+		err = req.Client.Post().
+			Namespace(namespace).
+			Resource(req.Resource).
+			Param("dryRun", metav1.DryRunAll).
+			Body(req.Object).
+			Do().
+			Error()
+		if errors.IsNotFound(err) {
+			// The namespace or another object referenced by the resource
+			// is created in the same apply, like values not known until apply
+			log.Printf("[DEBUG] Skipping server-side dry run of %s %q: %s", req.Resource, metadata.Name, err)
+			return nil
+		}
+	} else {
+		if req.Spec == nil || !diff.HasChange("spec") {
+			return nil
+		}
+		namespace, name, idErr := idParts(diff.Id())
+		if idErr != nil {
+			return idErr
+		}
+		ops := PatchOperations{
+			&ReplaceOperation{
+				Path:  "/spec",
+				Value: req.Spec,
+			},
+		}
+		if req.OldSpec != nil {
+			diffOps, diffErr := diffJSONPatch("/spec", req.OldSpec, req.Spec)
+			if diffErr != nil {
+				return fmt.Errorf("Failed to generate update operations: %s", diffErr)
+			}
+			ops = diffOps
+		}
+		data, marshalErr := ops.MarshalJSON()
+		if marshalErr != nil {
+			return fmt.Errorf("Failed to marshal update operations: %s", marshalErr)
+		}
+		log.Printf("[DEBUG] Dry running update of %s %q: %s", req.Resource, name, string(data))
+		err = req.Client.Patch(pkgApi.JSONPatchType).
+			Namespace(namespace).
+			Resource(req.Resource).
+			Name(name).
+			Param("dryRun", metav1.DryRunAll).
+			Body(data).
+			Do().
+			Error()
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			// Removed outside of Terraform, the refresh takes care of it
+			return nil
+		}
+	}
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 400 &&
+			strings.Contains(strings.ToLower(statusErr.ErrStatus.Message), "dryrun") {
+			// The DryRun feature gate is disabled
+			log.Printf("[WARN] Server-side dry run is not supported: %s", err)
+			return nil
+		}
+		return fmt.Errorf("Server-side dry run of %s failed: %s", req.Resource, translateAPIError(err, s))
+	}
+
+	return nil
+}
+
+// serverSupportsDryRun reports whether the API server accepts dryRun,
+// which is enabled by default since Kubernetes 1.13
+func (kp *kubernetesProvider) serverSupportsDryRun() (bool, error) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+
+	if kp.dryRunSupported != nil {
+		return *kp.dryRunSupported, nil
+	}
+
+	ver, err := kp.discoClient.ServerVersion()
+	if err != nil {
+		return false, err
+	}
+	// Managed offerings report versions like "1.13+"
+	major, _ := strconv.Atoi(strings.TrimRight(ver.Major, "+"))
+	minor, _ := strconv.Atoi(strings.TrimRight(ver.Minor, "+"))
+
+	supported := major > 1 || (major == 1 && minor >= 13)
+	log.Printf("[DEBUG] Kubernetes server %s.%s supports dry run: %t", ver.Major, ver.Minor, supported)
+	kp.dryRunSupported = &supported
+
+	return supported, nil
+}
+
+// diffUnknownValue returns the first changed attribute under the given
+// prefixes which is not known until apply. Attributes removed or set to
+// their zero value are known, they only read the same as unknown ones.
+func diffUnknownValue(diff *schema.ResourceDiff, s map[string]*schema.Schema, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		for _, key := range diff.GetChangedKeysPrefix(prefix) {
+			if strings.Contains(key, "~") {
+				// Set element with a computed hash
+				return key, true
+			}
+			// Counts of lists and maps are unknown when their elements are
+			sch := schemaForKey(s, strings.TrimSuffix(strings.TrimSuffix(key, ".#"), ".%"))
+			if sch == nil || sch.Computed {
+				// Computed attributes are either read-only or defaulted by the server
+				continue
+			}
+			if isNewComputed(diff, key) {
+				return key, true
+			}
+			if v, ok := diff.GetOk(key); ok {
+				if str, isString := v.(string); isString && str == config.UnknownVariableValue {
+					return key, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// isNewComputed reports whether the attribute diff of key is marked as
+// computed. The vendored ResourceDiff has no NewValueKnown and reads
+// unknown values as zero values, so the attribute diff is looked up in
+// its unexported InstanceDiff.
+func isNewComputed(diff *schema.ResourceDiff, key string) bool {
+	d := reflect.ValueOf(diff).Elem().FieldByName("diff")
+	if !d.IsValid() || d.IsNil() {
+		return false
+	}
+	attr := d.Elem().FieldByName("Attributes").MapIndex(reflect.ValueOf(key))
+	if !attr.IsValid() || attr.IsNil() {
+		return false
+	}
+	return attr.Elem().FieldByName("NewComputed").Bool()
+}
+
+// schemaForKey returns the schema of the primitive value at the given
+// flatmap key, e.g. spec.0.template.0.spec.0.container.0.name
+func schemaForKey(s map[string]*schema.Schema, key string) *schema.Schema {
+	parts := strings.Split(key, ".")
+	current := s
+	for i := 0; i < len(parts); i++ {
+		sch, ok := current[parts[i]]
+		if !ok {
+			return nil
+		}
+		if i == len(parts)-1 {
+			return sch
+		}
+		switch sch.Type {
+		case schema.TypeMap:
+			return &schema.Schema{Type: schema.TypeString}
+		case schema.TypeList, schema.TypeSet:
+			// Skip the index or hash of the element
+			i++
+			if i == len(parts)-1 {
+				if elem, ok := sch.Elem.(*schema.Schema); ok {
+					return elem
+				}
+				return nil
+			}
+			elem, ok := sch.Elem.(*schema.Resource)
+			if !ok {
+				return nil
+			}
+			current = elem.Schema
+		default:
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSchemaForKey(t *testing.T) {
+	s := resourceKubernetesDeployment().Schema
+
+	cases := []struct {
+		Key      string
+		Expected schema.ValueType
+		Found    bool
+	}{
+		{"metadata.0.name", schema.TypeString, true},
+		{"metadata.0.labels.app.kubernetes.io/name", schema.TypeString, true},
+		{"spec.0.replicas", schema.TypeInt, true},
+		{"spec.0.template.0.spec.0.container.0.port.1.container_port", schema.TypeInt, true},
+		{"spec.0.template.0.spec.0.container.0.args.2", schema.TypeString, true},
+		{"spec.0.template.0.spec.0.container.0.nonexistent", schema.TypeInvalid, false},
+		{"spec.0.replicas.0", schema.TypeInvalid, false},
+	}
+
+	for _, tc := range cases {
+		sch := schemaForKey(s, tc.Key)
+		if !tc.Found {
+			if sch != nil {
+				t.Fatalf("%s: expected no schema, got %#v", tc.Key, sch)
+			}
+			continue
+		}
+		if sch == nil {
+			t.Fatalf("%s: expected a schema", tc.Key)
+		}
+		if sch.Type != tc.Expected {
+			t.Fatalf("%s: expected type %s, got %s", tc.Key, tc.Expected, sch.Type)
+		}
+	}
+}
+
+func TestDiffUnknownValue(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"spec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"replicas": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+						"service_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	state := &terraform.InstanceState{
+		ID: "default/foo",
+		Attributes: map[string]string{
+			"spec.#":              "1",
+			"spec.0.replicas":     "3",
+			"spec.0.service_name": "foo",
+		},
+	}
+
+	cases := []struct {
+		Name        string
+		Config      map[string]interface{}
+		ExpectedKey string
+	}{
+		{
+			"removed",
+			map[string]interface{}{"spec": []interface{}{map[string]interface{}{"replicas": 3}}},
+			"",
+		},
+		{
+			"zero value",
+			map[string]interface{}{"spec": []interface{}{map[string]interface{}{"replicas": 0, "service_name": "foo"}}},
+			"",
+		},
+		{
+			"unknown",
+			map[string]interface{}{"spec": []interface{}{map[string]interface{}{"replicas": 3, "service_name": "${var.name}"}}},
+			"spec.0.service_name",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(tc.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = raw.Interpolate(map[string]ast.Variable{
+				"var.name": {Type: ast.TypeUnknown, Value: config.UnknownVariableValue},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var key string
+			var called bool
+			r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+				called = true
+				key, _ = diffUnknownValue(diff, r.Schema, "spec.")
+				return nil
+			}
+			_, err = r.Diff(state, terraform.NewResourceConfig(raw), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !called {
+				t.Fatal("Expected CustomizeDiff to be called")
+			}
+			if key != tc.ExpectedKey {
+				t.Fatalf("Expected unknown value %q, given %q", tc.ExpectedKey, key)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 
 	api "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,3 +72,237 @@ func stringifyEvents(events []api.Event) string {
 	}
 	return output
 }
+
+const (
+	// Diagnostics are attached to error messages, keep them readable
+	diagnosticsMaxPods       = 5
+	diagnosticsLogTailLines  = 20
+	diagnosticsLogLimitBytes = 4096
+)
+
+// workloadDiagnostics explains why a workload didn't reach the desired state
+type workloadDiagnostics struct {
+	Warnings        []api.Event
+	ContainerStates []containerState
+	ContainerLogs   []containerLog
+}
+
+type containerState struct {
+	Pod          string
+	Container    string
+	State        string
+	Reason       string
+	Message      string
+	RestartCount int32
+	LastReason   string
+	LastExitCode int32
+}
+
+type containerLog struct {
+	Pod       string
+	Container string
+	Previous  bool
+	ExitCode  int32
+	Reason    string
+	Log       string
+}
+
+// workloadWaitError decorates a failed or timed out wait with diagnostics
+// of the workload and the pods matching its selector
+func workloadWaitError(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, kind string, selector *meta_v1.LabelSelector, err error) error {
+	diag, dErr := collectWorkloadDiagnostics(conn, metadata, kind, selector)
+	if dErr != nil {
+		log.Printf("[WARN] Unable to collect diagnostics for %s %s/%s: %s",
+			kind, metadata.Namespace, metadata.Name, dErr)
+		return err
+	}
+	return fmt.Errorf("%s%s", err, diag)
+}
+
+// collectWorkloadDiagnostics gathers warning events of the workload,
+// states of containers in its unhealthy pods and the tail of logs
+// of crashed containers. For kind Pod the selector is ignored.
+func collectWorkloadDiagnostics(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, kind string, selector *meta_v1.LabelSelector) (*workloadDiagnostics, error) {
+	diag := &workloadDiagnostics{}
+
+	warnings, err := getLastWarningsForObject(conn, metadata, kind, 3)
+	if err != nil {
+		return nil, err
+	}
+	diag.Warnings = warnings
+
+	var pods []api.Pod
+	if kind == "Pod" {
+		pod, err := conn.CoreV1().Pods(metadata.Namespace).Get(metadata.Name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pods = []api.Pod{*pod}
+	} else if selector != nil && (len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0) {
+		out, err := conn.CoreV1().Pods(metadata.Namespace).List(meta_v1.ListOptions{
+			LabelSelector: meta_v1.FormatLabelSelector(selector),
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = out.Items
+	}
+
+	podCount := 0
+	for _, pod := range pods {
+		if podCount >= diagnosticsMaxPods {
+			break
+		}
+		if isPodHealthy(pod) {
+			continue
+		}
+		podCount++
+
+		if kind != "Pod" {
+			podWarnings, err := getLastWarningsForObject(conn, pod.ObjectMeta, "Pod", 3)
+			if err != nil {
+				return nil, err
+			}
+			diag.Warnings = append(diag.Warnings, podWarnings...)
+		}
+
+		states := summarizeContainerStates(pod)
+		diag.ContainerStates = append(diag.ContainerStates, states...)
+
+		for _, l := range crashedContainerLogs(pod) {
+			out, err := conn.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &api.PodLogOptions{
+				Container:  l.Container,
+				Previous:   l.Previous,
+				TailLines:  ptrToInt64(diagnosticsLogTailLines),
+				LimitBytes: ptrToInt64(diagnosticsLogLimitBytes),
+			}).DoRaw()
+			if err != nil {
+				log.Printf("[WARN] Unable to retrieve logs of %s/%s: %s", pod.Name, l.Container, err)
+				continue
+			}
+			l.Log = string(out)
+			diag.ContainerLogs = append(diag.ContainerLogs, l)
+		}
+	}
+
+	return diag, nil
+}
+
+func isPodHealthy(pod api.Pod) bool {
+	if pod.Status.Phase == api.PodSucceeded {
+		return true
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == api.PodReady {
+			return c.Status == api.ConditionTrue
+		}
+	}
+	return false
+}
+
+// summarizeContainerStates lists containers which aren't running
+// or were restarted, e.g. because of CrashLoopBackOff, ImagePullBackOff or OOMKilled
+func summarizeContainerStates(pod api.Pod) []containerState {
+	var states []containerState
+	statuses := podContainerStatuses(pod)
+	for _, cs := range statuses {
+		s := containerState{
+			Pod:          pod.Name,
+			Container:    cs.Name,
+			RestartCount: cs.RestartCount,
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			s.LastReason = t.Reason
+			s.LastExitCode = t.ExitCode
+		}
+
+		switch {
+		case cs.State.Waiting != nil:
+			s.State = "waiting"
+			s.Reason = cs.State.Waiting.Reason
+			s.Message = cs.State.Waiting.Message
+		case cs.State.Terminated != nil:
+			if cs.State.Terminated.ExitCode == 0 {
+				continue
+			}
+			s.State = "terminated"
+			s.Reason = cs.State.Terminated.Reason
+			s.Message = cs.State.Terminated.Message
+			s.LastExitCode = cs.State.Terminated.ExitCode
+		case cs.State.Running != nil && cs.RestartCount > 0:
+			s.State = "running"
+		default:
+			continue
+		}
+		states = append(states, s)
+	}
+	return states
+}
+
+// crashedContainerLogs returns log requests for containers which terminated
+// with non-zero exit code, preferring the previous instance of restarted containers
+func crashedContainerLogs(pod api.Pod) []containerLog {
+	var logs []containerLog
+	statuses := podContainerStatuses(pod)
+	for _, cs := range statuses {
+		if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+			logs = append(logs, containerLog{
+				Pod:       pod.Name,
+				Container: cs.Name,
+				ExitCode:  t.ExitCode,
+				Reason:    t.Reason,
+			})
+			continue
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil && t.ExitCode != 0 {
+			logs = append(logs, containerLog{
+				Pod:       pod.Name,
+				Container: cs.Name,
+				Previous:  true,
+				ExitCode:  t.ExitCode,
+				Reason:    t.Reason,
+			})
+		}
+	}
+	return logs
+}
+
+func podContainerStatuses(pod api.Pod) []api.ContainerStatus {
+	statuses := make([]api.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
+
+func (wd *workloadDiagnostics) String() string {
+	output := stringifyEvents(wd.Warnings)
+
+	for _, s := range wd.ContainerStates {
+		output += fmt.Sprintf("\n   * %s/%s: %s", s.Pod, s.Container, s.State)
+		if s.Reason != "" {
+			output += fmt.Sprintf(" (%s)", s.Reason)
+		}
+		if s.Message != "" {
+			output += ": " + s.Message
+		}
+		if s.RestartCount > 0 {
+			output += fmt.Sprintf(", restarts: %d", s.RestartCount)
+		}
+		if s.LastReason != "" {
+			output += fmt.Sprintf(", last terminated: %s (exit code %d)", s.LastReason, s.LastExitCode)
+		}
+	}
+
+	for _, l := range wd.ContainerLogs {
+		instance := "current"
+		if l.Previous {
+			instance = "previous"
+		}
+		output += fmt.Sprintf("\n   * logs of %s/%s (%s instance, %s, exit code %d):",
+			l.Pod, l.Container, instance, l.Reason, l.ExitCode)
+		for _, line := range strings.Split(strings.TrimRight(l.Log, "\n"), "\n") {
+			output += "\n       " + line
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,148 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSummarizeContainerStates(t *testing.T) {
+	pod := api.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Status: api.PodStatus{
+			InitContainerStatuses: []api.ContainerStatus{
+				{
+					Name:  "init",
+					State: api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+				},
+			},
+			ContainerStatuses: []api.ContainerStatus{
+				{
+					Name:         "app",
+					RestartCount: 4,
+					State: api.ContainerState{Waiting: &api.ContainerStateWaiting{
+						Reason:  "CrashLoopBackOff",
+						Message: "Back-off 40s restarting failed container",
+					}},
+					LastTerminationState: api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+				},
+				{
+					Name:  "sidecar",
+					State: api.ContainerState{Waiting: &api.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				},
+				{
+					Name:  "healthy",
+					State: api.ContainerState{Running: &api.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+
+	expected := []containerState{
+		{
+			Pod:          "web-1",
+			Container:    "app",
+			State:        "waiting",
+			Reason:       "CrashLoopBackOff",
+			Message:      "Back-off 40s restarting failed container",
+			RestartCount: 4,
+			LastReason:   "OOMKilled",
+			LastExitCode: 137,
+		},
+		{
+			Pod:       "web-1",
+			Container: "sidecar",
+			State:     "waiting",
+			Reason:    "ImagePullBackOff",
+		},
+	}
+
+	states := summarizeContainerStates(pod)
+	if !reflect.DeepEqual(states, expected) {
+		t.Fatalf("Unexpected container states.\nExpected: %#v\nGiven:    %#v", expected, states)
+	}
+}
+
+func TestCrashedContainerLogs(t *testing.T) {
+	pod := api.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate-x7k2"},
+		Status: api.PodStatus{
+			ContainerStatuses: []api.ContainerStatus{
+				{
+					Name:  "failed",
+					State: api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 3, Reason: "Error"}},
+				},
+				{
+					Name:                 "restarted",
+					State:                api.ContainerState{Running: &api.ContainerStateRunning{}},
+					LastTerminationState: api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+				},
+				{
+					Name:  "succeeded",
+					State: api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+				},
+			},
+		},
+	}
+
+	expected := []containerLog{
+		{Pod: "migrate-x7k2", Container: "failed", ExitCode: 3, Reason: "Error"},
+		{Pod: "migrate-x7k2", Container: "restarted", Previous: true, ExitCode: 1, Reason: "Error"},
+	}
+
+	logs := crashedContainerLogs(pod)
+	if !reflect.DeepEqual(logs, expected) {
+		t.Fatalf("Unexpected log requests.\nExpected: %#v\nGiven:    %#v", expected, logs)
+	}
+}
+
+func TestIsPodHealthy(t *testing.T) {
+	testCases := []struct {
+		Status   api.PodStatus
+		Expected bool
+	}{
+		{api.PodStatus{Phase: api.PodSucceeded}, true},
+		{api.PodStatus{Phase: api.PodPending}, false},
+		{api.PodStatus{
+			Phase:      api.PodRunning,
+			Conditions: []api.PodCondition{{Type: api.PodReady, Status: api.ConditionTrue}},
+		}, true},
+		{api.PodStatus{
+			Phase:      api.PodRunning,
+			Conditions: []api.PodCondition{{Type: api.PodReady, Status: api.ConditionFalse}},
+		}, false},
+	}
+
+	for i, tc := range testCases {
+		if isPodHealthy(api.Pod{Status: tc.Status}) != tc.Expected {
+			t.Fatalf("%d: expected pod health to be %t", i, tc.Expected)
+		}
+	}
+}
+
+func TestWorkloadDiagnosticsString(t *testing.T) {
+	diag := workloadDiagnostics{
+		ContainerStates: []containerState{
+			{Pod: "web-1", Container: "app", State: "waiting", Reason: "CrashLoopBackOff", RestartCount: 2, LastReason: "Error", LastExitCode: 1},
+		},
+		ContainerLogs: []containerLog{
+			{Pod: "web-1", Container: "app", Previous: true, ExitCode: 1, Reason: "Error", Log: "starting\npanic: boom\n"},
+		},
+	}
+
+	expected := []string{
+		"\n   * web-1/app: waiting (CrashLoopBackOff), restarts: 2, last terminated: Error (exit code 1)",
+		"\n   * logs of web-1/app (previous instance, Error, exit code 1):",
+		"\n       starting\n       panic: boom",
+	}
+
+	output := diag.String()
+	for _, e := range expected {
+		if !strings.Contains(output, e) {
+			t.Fatalf("Expected output to contain %q, given:\n%s", e, output)
+		}
+	}
+}
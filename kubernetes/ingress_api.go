@@ -0,0 +1,162 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+)
+
+const ingressesResourceGroupName = "ingresses"
+
+var ingressesAPIGroups = []APIGroup{networkingV1beta1, extensionsV1beta1}
+
+var ingressNotSupportedError = errors.New("could not find Kubernetes API group that supports Ingress resources")
+
+// ingressInterface is the subset of the typed Ingress client used by the
+// provider, so both API groups serving Ingress can be used interchangeably
+type ingressInterface interface {
+	Create(*v1beta1.Ingress) (*v1beta1.Ingress, error)
+	Delete(name string, options *meta_v1.DeleteOptions) error
+	Get(name string, options meta_v1.GetOptions) (*v1beta1.Ingress, error)
+}
+
+// ingresses returns a client for the highest Ingress group-version
+// served by the cluster
+func (kp *kubernetesProvider) ingresses(namespace string) (ingressInterface, error) {
+	apiGroup, err := kp.highestSupportedAPIGroup(ingressesResourceGroupName, ingressesAPIGroups...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch apiGroup {
+	case networkingV1beta1:
+		client, err := networkingV1beta1RESTClient(kp.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &networkingV1beta1Ingresses{client: client, ns: namespace}, nil
+
+	case extensionsV1beta1:
+		return kp.conn.ExtensionsV1beta1().Ingresses(namespace), nil
+
+	default:
+		return nil, ingressNotSupportedError
+	}
+}
+
+// patchIngress sends the update of the named ingress to the given Ingress
+// group-version. The result is decoded as raw JSON, like networking.k8s.io/v1beta1
+// objects are.
+func (kp *kubernetesProvider) patchIngress(apiGroup APIGroup, namespace, name string, patch *updatePatch) (*v1beta1.Ingress, error) {
+	var client restclient.Interface
+	switch apiGroup {
+	case networkingV1beta1:
+		c, err := networkingV1beta1RESTClient(kp.cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = c
+
+	case extensionsV1beta1:
+		client = kp.conn.ExtensionsV1beta1().RESTClient()
+
+	default:
+		return nil, ingressNotSupportedError
+	}
+
+	data, err := patch.request(client, ingressesResourceGroupName, namespace, name).Do().Raw()
+	return unmarshalNetworkingV1beta1Ingress(data, err)
+}
+
+// networkingV1beta1RESTClient builds a client for networking.k8s.io/v1beta1,
+// which isn't part of the vendored client-go
+func networkingV1beta1RESTClient(cfg *restclient.Config) (*restclient.RESTClient, error) {
+	config := restclient.CopyConfig(cfg)
+	config.GroupVersion = &k8sschema.GroupVersion{Group: "networking.k8s.io", Version: "v1beta1"}
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: scheme.Codecs}
+	if config.UserAgent == "" {
+		config.UserAgent = restclient.DefaultKubernetesUserAgent()
+	}
+	return restclient.RESTClientFor(config)
+}
+
+// networkingV1beta1Ingresses serves Ingress from networking.k8s.io/v1beta1.
+// The object schema is identical to extensions/v1beta1, so objects are
+// exchanged as raw JSON with only the apiVersion swapped.
+type networkingV1beta1Ingresses struct {
+	client restclient.Interface
+	ns     string
+}
+
+func (c *networkingV1beta1Ingresses) Create(ingress *v1beta1.Ingress) (*v1beta1.Ingress, error) {
+	body, err := marshalNetworkingV1beta1Ingress(ingress)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.client.Post().
+		Namespace(c.ns).
+		Resource(ingressesResourceGroupName).
+		Body(body).
+		Do().
+		Raw()
+	return unmarshalNetworkingV1beta1Ingress(data, err)
+}
+
+func (c *networkingV1beta1Ingresses) Delete(name string, options *meta_v1.DeleteOptions) error {
+	body, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource(ingressesResourceGroupName).
+		Name(name).
+		Body(body).
+		Do().
+		Error()
+}
+
+func (c *networkingV1beta1Ingresses) Get(name string, options meta_v1.GetOptions) (*v1beta1.Ingress, error) {
+	req := c.client.Get().
+		Namespace(c.ns).
+		Resource(ingressesResourceGroupName).
+		Name(name)
+	if options.ResourceVersion != "" {
+		req = req.Param("resourceVersion", options.ResourceVersion)
+	}
+	data, err := req.Do().Raw()
+	return unmarshalNetworkingV1beta1Ingress(data, err)
+}
+
+func marshalNetworkingV1beta1Ingress(ingress *v1beta1.Ingress) ([]byte, error) {
+	in := ingress.DeepCopy()
+	in.APIVersion = networkingV1beta1.String()
+	in.Kind = "Ingress"
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal ingress: %s", err)
+	}
+	return data, nil
+}
+
+func unmarshalNetworkingV1beta1Ingress(data []byte, err error) (*v1beta1.Ingress, error) {
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.Ingress{}
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal ingress: %s", err)
+	}
+	log.Printf("[DEBUG] Received %s ingress %s", out.APIVersion, out.Name)
+	return out, nil
+}
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -152,3 +153,240 @@ func (o *RemoveOperation) String() string {
 	b, _ := o.MarshalJSON()
 	return string(b)
 }
+
+type TestOperation struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+	Op    string      `json:"op"`
+}
+
+func (o *TestOperation) GetPath() string {
+	return o.Path
+}
+
+func (o *TestOperation) MarshalJSON() ([]byte, error) {
+	o.Op = "test"
+	return json.Marshal(*o)
+}
+
+func (o *TestOperation) String() string {
+	b, _ := o.MarshalJSON()
+	return string(b)
+}
+
+// mergeKeys identify the elements of lists of objects, the first key with
+// a unique value in every element is used (e.g. the name of a container)
+var mergeKeys = []string{"name", "mountPath", "containerPort", "port"}
+
+// diffJSONPatch generates the JSON patch (RFC 6902) turning oldV into newV,
+// both are API objects which are compared through their JSON representation.
+// Unlike replacing the whole object, fields defaulted by the API server or
+// managed by other controllers are left untouched unless they changed.
+func diffJSONPatch(pathPrefix string, oldV, newV interface{}) (PatchOperations, error) {
+	oldJSON, err := toJSONValue(oldV)
+	if err != nil {
+		return nil, err
+	}
+	newJSON, err := toJSONValue(newV)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]PatchOperation, 0, 0)
+	diffJSONValue(strings.TrimRight(pathPrefix, "/"), oldJSON, newJSON, &ops)
+	return ops, nil
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+func diffJSONValue(path string, oldV, newV interface{}, ops *[]PatchOperation) {
+	if reflect.DeepEqual(oldV, newV) {
+		return
+	}
+
+	switch n := newV.(type) {
+	case map[string]interface{}:
+		if o, ok := oldV.(map[string]interface{}); ok {
+			diffJSONObject(path, o, n, ops)
+			return
+		}
+	case []interface{}:
+		if o, ok := oldV.([]interface{}); ok {
+			diffJSONArray(path, o, n, ops)
+			return
+		}
+	}
+
+	*ops = append(*ops, &ReplaceOperation{
+		Path:  path,
+		Value: newV,
+	})
+}
+
+func diffJSONObject(path string, oldV, newV map[string]interface{}, ops *[]PatchOperation) {
+	keys := make([]string, 0, len(oldV)+len(newV))
+	for k := range oldV {
+		keys = append(keys, k)
+	}
+	for k := range newV {
+		if _, ok := oldV[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapeJsonPointer(k)
+		o, inOld := oldV[k]
+		n, inNew := newV[k]
+		switch {
+		case !inNew:
+			*ops = append(*ops, &RemoveOperation{
+				Path: p,
+			})
+		case !inOld:
+			// Add replaces the member if it was set outside of Terraform
+			*ops = append(*ops, &AddOperation{
+				Path:  p,
+				Value: n,
+			})
+		default:
+			diffJSONValue(p, o, n, ops)
+		}
+	}
+}
+
+func diffJSONArray(path string, oldV, newV []interface{}, ops *[]PatchOperation) {
+	if key := arrayMergeKey(oldV, newV); key != "" {
+		if diffJSONArrayByKey(path, key, oldV, newV, ops) {
+			return
+		}
+	} else if len(oldV) == len(newV) {
+		for i := range newV {
+			diffJSONValue(fmt.Sprintf("%s/%d", path, i), oldV[i], newV[i], ops)
+		}
+		return
+	}
+
+	*ops = append(*ops, &ReplaceOperation{
+		Path:  path,
+		Value: newV,
+	})
+}
+
+// diffJSONArrayByKey patches the elements of a list identified by a merge key,
+// each operation on an existing element is preceded by a test of its key so
+// the patch fails instead of changing another element when the list has been
+// reordered since the last refresh. Returns false when the order of the
+// elements changed, the list needs to be replaced then.
+func diffJSONArrayByKey(path, key string, oldV, newV []interface{}, ops *[]PatchOperation) bool {
+	oldIndex := make(map[interface{}]int, len(oldV))
+	for i, v := range oldV {
+		oldIndex[v.(map[string]interface{})[key]] = i
+	}
+	newKeys := make(map[interface{}]bool, len(newV))
+	for _, v := range newV {
+		newKeys[v.(map[string]interface{})[key]] = true
+	}
+
+	// Kept elements have to stay in the same order and new elements
+	// can only be appended, JSON patch has no way to move by key
+	last := -1
+	appended := false
+	for _, v := range newV {
+		i, ok := oldIndex[v.(map[string]interface{})[key]]
+		if !ok {
+			appended = true
+			continue
+		}
+		if appended || i < last {
+			return false
+		}
+		last = i
+	}
+
+	test := func(i int, keyValue interface{}) {
+		*ops = append(*ops, &TestOperation{
+			Path:  fmt.Sprintf("%s/%d/%s", path, i, escapeJsonPointer(key)),
+			Value: keyValue,
+		})
+	}
+
+	// Changes first, while the indexes still match the old list
+	for _, v := range newV {
+		keyValue := v.(map[string]interface{})[key]
+		i, ok := oldIndex[keyValue]
+		if !ok || reflect.DeepEqual(oldV[i], v) {
+			continue
+		}
+		test(i, keyValue)
+		diffJSONValue(fmt.Sprintf("%s/%d", path, i), oldV[i], v, ops)
+	}
+
+	// Removals from the end so the indexes of the remaining ones don't shift
+	for i := len(oldV) - 1; i >= 0; i-- {
+		keyValue := oldV[i].(map[string]interface{})[key]
+		if newKeys[keyValue] {
+			continue
+		}
+		test(i, keyValue)
+		*ops = append(*ops, &RemoveOperation{
+			Path: fmt.Sprintf("%s/%d", path, i),
+		})
+	}
+
+	for _, v := range newV {
+		if _, ok := oldIndex[v.(map[string]interface{})[key]]; ok {
+			continue
+		}
+		*ops = append(*ops, &AddOperation{
+			Path:  path + "/-",
+			Value: v,
+		})
+	}
+
+	return true
+}
+
+// arrayMergeKey returns the merge key identifying the elements of both lists,
+// or an empty string if they aren't lists of objects with a unique key
+func arrayMergeKey(oldV, newV []interface{}) string {
+	for _, key := range mergeKeys {
+		if hasUniqueKey(oldV, key) && hasUniqueKey(newV, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasUniqueKey(list []interface{}, key string) bool {
+	seen := make(map[interface{}]bool, len(list))
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		keyValue, ok := m[key]
+		if !ok {
+			return false
+		}
+		switch keyValue.(type) {
+		case string, float64:
+		default:
+			return false
+		}
+		if seen[keyValue] {
+			return false
+		}
+		seen[keyValue] = true
+	}
+	return true
+}
@@ -165,3 +165,119 @@ func TestEscapeJsonPointer(t *testing.T) {
 		}
 	}
 }
+
+func TestTestOperationMarshalJSON(t *testing.T) {
+	ops := PatchOperations{
+		&TestOperation{
+			Path:  "/metadata/resourceVersion",
+			Value: "1234",
+		},
+		&RemoveOperation{
+			Path: "/metadata/labels/foo",
+		},
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"path":"/metadata/resourceVersion","value":"1234","op":"test"},{"path":"/metadata/labels/foo","op":"remove"}]`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, given: %s", expected, string(data))
+	}
+}
+
+func TestDiffJSONPatch(t *testing.T) {
+	container := func(name, image string, args ...string) map[string]interface{} {
+		c := map[string]interface{}{"name": name, "image": image}
+		if len(args) > 0 {
+			c["args"] = args
+		}
+		return c
+	}
+	spec := func(replicas int, containers ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": containers,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Old      interface{}
+		New      interface{}
+		Expected string
+	}{
+		{
+			Old:      spec(1, container("app", "nginx:1.14")),
+			New:      spec(1, container("app", "nginx:1.14")),
+			Expected: `[]`,
+		},
+		{
+			Old:      spec(1, container("app", "nginx:1.14")),
+			New:      spec(3, container("app", "nginx:1.14")),
+			Expected: `[{"path":"/spec/replicas","value":3,"op":"replace"}]`,
+		},
+		{
+			Old: spec(1, container("app", "nginx:1.14"), container("sidecar", "envoy")),
+			New: spec(1, container("app", "nginx:1.14"), container("sidecar", "envoy:v2")),
+			Expected: `[{"path":"/spec/template/spec/containers/1/name","value":"sidecar","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/1/image","value":"envoy:v2","op":"replace"}]`,
+		},
+		{
+			Old: spec(1, container("app", "nginx:1.14")),
+			New: spec(1, container("app", "nginx:1.14", "-g", "daemon off;")),
+			Expected: `[{"path":"/spec/template/spec/containers/0/name","value":"app","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/0/args","value":["-g","daemon off;"],"op":"add"}]`,
+		},
+		{
+			Old: spec(1, container("app", "nginx:1.14", "-g", "daemon off;")),
+			New: spec(1, container("app", "nginx:1.14", "-g", "daemon on;")),
+			Expected: `[{"path":"/spec/template/spec/containers/0/name","value":"app","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/0/args/1","value":"daemon on;","op":"replace"}]`,
+		},
+		{
+			Old: spec(1, container("app", "nginx:1.14", "-g", "daemon off;")),
+			New: spec(1, container("app", "nginx:1.14", "-c")),
+			Expected: `[{"path":"/spec/template/spec/containers/0/name","value":"app","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/0/args","value":["-c"],"op":"replace"}]`,
+		},
+		{
+			Old: spec(1, container("init", "busybox"), container("app", "nginx:1.14"), container("sidecar", "envoy")),
+			New: spec(1, container("app", "nginx:1.15"), container("metrics", "exporter")),
+			Expected: `[{"path":"/spec/template/spec/containers/1/name","value":"app","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/1/image","value":"nginx:1.15","op":"replace"},` +
+				`{"path":"/spec/template/spec/containers/2/name","value":"sidecar","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/2","op":"remove"},` +
+				`{"path":"/spec/template/spec/containers/0/name","value":"init","op":"test"},` +
+				`{"path":"/spec/template/spec/containers/0","op":"remove"},` +
+				`{"path":"/spec/template/spec/containers/-","value":{"image":"exporter","name":"metrics"},"op":"add"}]`,
+		},
+		{
+			Old:      spec(1, container("app", "nginx:1.14"), container("sidecar", "envoy")),
+			New:      spec(1, container("sidecar", "envoy"), container("app", "nginx:1.14")),
+			Expected: `[{"path":"/spec/template/spec/containers","value":[{"image":"envoy","name":"sidecar"},{"image":"nginx:1.14","name":"app"}],"op":"replace"}]`,
+		},
+		{
+			Old:      map[string]interface{}{"paused": true, "strategy": map[string]interface{}{"type": "Recreate"}},
+			New:      map[string]interface{}{"strategy": map[string]interface{}{"type": "RollingUpdate"}},
+			Expected: `[{"path":"/spec/paused","op":"remove"},{"path":"/spec/strategy/type","value":"RollingUpdate","op":"replace"}]`,
+		},
+	}
+
+	for i, tc := range testCases {
+		ops, err := diffJSONPatch("/spec", tc.Old, tc.New)
+		if err != nil {
+			t.Fatalf("Test case %d: %s", i, err)
+		}
+		data, err := ops.MarshalJSON()
+		if err != nil {
+			t.Fatalf("Test case %d: %s", i, err)
+		}
+		if string(data) != tc.Expected {
+			t.Fatalf("Test case %d: expected:\n%s\ngiven:\n%s", i, tc.Expected, string(data))
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-homedir"
 	"k8s.io/client-go/discovery"
@@ -24,15 +25,20 @@ import (
 )
 
 type kubernetesProvider struct {
-	cfg               *restclient.Config
-	conn              *kubernetes.Clientset
-	discoveryCacheDir string
-	discoClient       *CachedDiscoveryClient
-	mu                sync.Mutex
+	cfg                   *restclient.Config
+	conn                  *kubernetes.Clientset
+	discoveryCacheDir     string
+	discoClient           *CachedDiscoveryClient
+	serverDryRun          bool
+	optimisticConcurrency bool
+	updateStrategy        string
+	forceConflicts        bool
+	dryRunSupported       *bool
+	mu                    sync.Mutex
 }
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -116,6 +122,31 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_LOAD_CONFIG_FILE", true),
 				Description: "Load local kubeconfig.",
 			},
+			"server_dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_SERVER_DRY_RUN", false),
+				Description: "Validate planned workloads with a server-side dry run (requires Kubernetes 1.13+).",
+			},
+			"optimistic_concurrency": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_OPTIMISTIC_CONCURRENCY", false),
+				Description: "Fail updates of resources modified outside of Terraform since the last refresh instead of overwriting the changes.",
+			},
+			"update_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBE_UPDATE_STRATEGY", updateStrategyJSONPatch),
+				ValidateFunc: validation.StringInSlice(updateStrategies, false),
+				Description:  "How resources are updated: json_patch, strategic_merge or server_side_apply (requires Kubernetes 1.16+).",
+			},
+			"force_conflicts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_FORCE_CONFLICTS", false),
+				Description: "Take ownership of fields managed by other field managers when updating with server-side apply.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -132,6 +163,8 @@ func Provider() terraform.ResourceProvider {
 			"kubernetes_horizontal_pod_autoscaler": resourceKubernetesHorizontalPodAutoscaler(),
 			"kubernetes_job":                       resourceKubernetesJob(),
 			"kubernetes_cron_job":                  resourceKubernetesCronJob(),
+			"kubernetes_cron_job_run":              resourceKubernetesCronJobRun(),
+			"kubernetes_endpoints":                 resourceKubernetesEndpoints(),
 			"kubernetes_ingress":                   resourceKubernetesIngress(),
 			"kubernetes_limit_range":               resourceKubernetesLimitRange(),
 			"kubernetes_namespace":                 resourceKubernetesNamespace(),
@@ -153,6 +186,12 @@ func Provider() terraform.ResourceProvider {
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	for _, r := range p.ResourcesMap {
+		translateResourceAPIErrors(r)
+	}
+
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -205,8 +244,12 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	providerInstance := &kubernetesProvider{
-		conn: k,
-		cfg:  cfg,
+		conn:                  k,
+		cfg:                   cfg,
+		serverDryRun:          d.Get("server_dry_run").(bool),
+		optimisticConcurrency: d.Get("optimistic_concurrency").(bool),
+		updateStrategy:        d.Get("update_strategy").(string),
+		forceConflicts:        d.Get("force_conflicts").(bool),
 	}
 
 	err = providerInstance.prepareDiscoveryCacheClient(d)
@@ -1,14 +1,13 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesConfigMap() *schema.Resource {
@@ -89,14 +88,15 @@ func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{})
 		diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
 		ops = append(ops, diffOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "ConfigMap", expandConfigMapObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating config map %q: %v", name, string(data))
-	out, err := conn.CoreV1().ConfigMaps(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating config map %q: %v", name, patch)
+	out := &api.ConfigMap{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "configmaps", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update Config Map: %s", err)
+		return wrapAPIError(err, "Failed to update Config Map")
 	}
 	log.Printf("[INFO] Submitted updated config map: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -104,6 +104,14 @@ func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{})
 	return resourceKubernetesConfigMapRead(d, meta)
 }
 
+// expandConfigMapObject expands the config map of the given state or plan
+func expandConfigMapObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.ConfigMap{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Data:       expandStringMap(get("data").(map[string]interface{})),
+	}, nil
+}
+
 func resourceKubernetesConfigMapDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
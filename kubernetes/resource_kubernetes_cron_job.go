@@ -7,20 +7,24 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/batch/v1beta1"
 	"k8s.io/api/batch/v2alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const cronJobResourceGroupName = "cronjobs"
 
 var cronJobAPIGroups = []APIGroup{batchV1beta1, batchV2alpha1}
 
+const cronJobNextScheduleTimesCount = 5
+
 var cronJobNotSupportedError = fmt.Errorf("could not find Kubernetes API group that supports CronJob resources")
 
 func resourceKubernetesCronJob() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceKubernetesCronJobCreate,
 		Read:   resourceKubernetesCronJobRead,
 		Update: resourceKubernetesCronJobUpdate,
@@ -29,8 +33,11 @@ func resourceKubernetesCronJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceKubernetesCronJobStateUpgrader,
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("cronjob", true),
+			"metadata":             namespacedMetadataSchema("cronjob", true),
+			"deletion_propagation": deletionPropagationSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the cron job owned by the cluster",
@@ -40,8 +47,47 @@ func resourceKubernetesCronJob() *schema.Resource {
 					Schema: cronJobSpecFields(),
 				},
 			},
+			"next_schedule_times": {
+				Type:        schema.TypeList,
+				Description: fmt.Sprintf("The next %d times (RFC3339, UTC) the job is scheduled to run. Empty while the cron job is suspended.", cronJobNextScheduleTimesCount),
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_schedule_time": {
+				Type:        schema.TypeString,
+				Description: "Information when was the last time the job was successfully scheduled (RFC3339).",
+				Computed:    true,
+			},
+			"active": {
+				Type:        schema.TypeList,
+				Description: "Pointers to currently running jobs.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
+
+	// The server-side dry run needs the schema, it's only built once
+	r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesCronJobCustomizeDiff(diff, meta, r.Schema)
+	}
+
+	return r
 }
 
 func resourceKubernetesCronJobCreate(d *schema.ResourceData, meta interface{}) error {
@@ -96,57 +142,89 @@ func resourceKubernetesCronJobCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesCronJobUpdate(d *schema.ResourceData, meta interface{}) error {
-	kp := meta.(*kubernetesProvider)
-	conn := kp.conn
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	// The annotations of the job template follow the ones of the cron job
+	if d.HasChange("spec") || d.HasChange("metadata.0.annotations") {
+		oldObj, err := expandCronJobObject(func(key string) interface{} {
+			o, _ := d.GetChange(key)
+			return o
+		})
+		if err != nil {
+			return err
+		}
+		newObj, err := expandCronJobObject(d.Get)
+		if err != nil {
+			return err
+		}
 
-	namespace, _, err := idParts(d.Id())
+		specOps, err := diffJSONPatch("/spec", oldObj.(*v1beta1.CronJob).Spec, newObj.(*v1beta1.CronJob).Spec)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "batch/v1beta1", "CronJob", expandCronJobObject)
 	if err != nil {
 		return err
 	}
+	log.Printf("[INFO] Updating cron job %q: %v", name, patch)
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	spec, err := expandCronJobSpec(d.Get("spec").([]interface{}))
+	out, err := patchCronJob(meta.(*kubernetesProvider), namespace, name, patch)
 	if err != nil {
 		return err
 	}
+	log.Printf("[INFO] Submitted updated cron job: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+	return resourceKubernetesCronJobRead(d, meta)
+}
+
+// expandCronJobObject expands the cron job of the given state or plan
+func expandCronJobObject(get func(key string) interface{}) (runtime.Object, error) {
+	metadata := expandMetadata(get("metadata").([]interface{}))
+	spec, err := expandCronJobSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
 	spec.JobTemplate.ObjectMeta.Annotations = metadata.Annotations
 
-	cronjob := &v1beta1.CronJob{
+	return &v1beta1.CronJob{
 		ObjectMeta: metadata,
 		Spec:       spec,
-	}
-
-	log.Printf("[INFO] Updating cron job %s: %s", d.Id(), cronjob)
+	}, nil
+}
 
+func patchCronJob(kp *kubernetesProvider, namespace, name string, patch *updatePatch) (*v1beta1.CronJob, error) {
 	out := &v1beta1.CronJob{}
 	apiGroup, err := kp.highestSupportedAPIGroup(cronJobResourceGroupName, cronJobAPIGroups...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	switch apiGroup {
 	case batchV1beta1:
-		out, err = conn.BatchV1beta1().CronJobs(namespace).Update(cronjob)
+		err = patch.apply(kp.conn.BatchV1beta1().RESTClient(), cronJobResourceGroupName, namespace, name, out)
 
 	case batchV2alpha1:
 		alpha := &v2alpha1.CronJob{}
-		Convert(cronjob, alpha)
-		alphaOut, err2 := conn.BatchV2alpha1().CronJobs(namespace).Update(alpha)
-		if err2 != nil {
-			err = err2
+		err = patch.apply(kp.conn.BatchV2alpha1().RESTClient(), cronJobResourceGroupName, namespace, name, alpha)
+		if err != nil {
 			break
 		}
-		Convert(alphaOut, out)
+		err = Convert(alpha, out)
 
 	default:
 		err = cronJobNotSupportedError
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
-	log.Printf("[INFO] Submitted updated cron job: %#v", out)
-
-	d.SetId(buildId(out.ObjectMeta))
-	return resourceKubernetesCronJobRead(d, meta)
+	return out, nil
 }
 
 func resourceKubernetesCronJobRead(d *schema.ResourceData, meta interface{}) error {
@@ -202,9 +280,71 @@ func resourceKubernetesCronJobRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	suspend := job.Spec.Suspend != nil && *job.Spec.Suspend
+	nextTimes, err := cronJobNextScheduleTimes(job.Spec.Schedule, suspend, time.Now(), cronJobNextScheduleTimesCount)
+	if err != nil {
+		return err
+	}
+	err = d.Set("next_schedule_times", nextTimes)
+	if err != nil {
+		return err
+	}
+
+	lastScheduleTime := ""
+	if job.Status.LastScheduleTime != nil {
+		lastScheduleTime = job.Status.LastScheduleTime.UTC().Format(time.RFC3339)
+	}
+	d.Set("last_schedule_time", lastScheduleTime)
+
+	err = d.Set("active", flattenObjectReferences(job.Status.Active))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func resourceKubernetesCronJobCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	spec, err := expandCronJobSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of cron job spec: %s", err)
+	} else {
+		err := validateJobSpec("spec.0.job_template.0.spec.0", spec.JobTemplate.Spec)
+		if err != nil {
+			return err
+		}
+
+		err = dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+			oldSpec, _ := diff.GetChange("spec")
+			oldObj, err := expandCronJobSpec(oldSpec.([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			return &dryRunRequest{
+				Client:   meta.(*kubernetesProvider).conn.BatchV1beta1().RESTClient(),
+				Resource: cronJobResourceGroupName,
+				Object: &v1beta1.CronJob{
+					ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+					Spec:       spec,
+				},
+				Spec:    spec,
+				OldSpec: oldObj,
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !diff.HasChange("spec.0.schedule") && !diff.HasChange("spec.0.suspend") {
+		return nil
+	}
+
+	// The times depend on the clock, they are only known once read after
+	// the apply as the diff is computed again when applying
+	return diff.SetNewComputed("next_schedule_times")
+}
+
 func resourceKubernetesCronJobDelete(d *schema.ResourceData, meta interface{}) error {
 	kp := meta.(*kubernetesProvider)
 	conn := kp.conn
@@ -221,10 +361,10 @@ func resourceKubernetesCronJobDelete(d *schema.ResourceData, meta interface{}) e
 	}
 	switch apiGroup {
 	case batchV1beta1:
-		err = conn.BatchV1beta1().CronJobs(namespace).Delete(name, nil)
+		err = conn.BatchV1beta1().CronJobs(namespace).Delete(name, expandDeleteOptions(d))
 
 	case batchV2alpha1:
-		err = conn.BatchV2alpha1().CronJobs(namespace).Delete(name, nil)
+		err = conn.BatchV2alpha1().CronJobs(namespace).Delete(name, expandDeleteOptions(d))
 
 	default:
 		err = cronJobNotSupportedError
@@ -304,3 +444,35 @@ func readCronJob(kp *kubernetesProvider, namespace, name string) (cj *v1beta1.Cr
 
 	return cj, err
 }
+
+func resourceKubernetesCronJobStateUpgrader(
+	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	var err error
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Kubernetes CronJob State v0; migrating to v1")
+		is, err = migrateCronJobStateV0toV1(is)
+
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+
+	return is, err
+}
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateCronJobStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func resourceKubernetesCronJobRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesCronJobRunCreate,
+		Read:   resourceKubernetesCronJobRunRead,
+		Delete: resourceKubernetesCronJobRunDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cron_job_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the cron job to run.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the cron job. The job is created in the same namespace.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, run the cron job again.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the job to complete. The apply fails if the job fails, the error includes warning events and logs of the failed containers.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"job_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the job created from the cron job's job template.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesCronJobRunCreate(d *schema.ResourceData, meta interface{}) error {
+	kp := meta.(*kubernetesProvider)
+	conn := kp.conn
+
+	namespace := d.Get("namespace").(string)
+	cronJobName := d.Get("cron_job_name").(string)
+
+	apiGroup, err := kp.highestSupportedAPIGroup(cronJobResourceGroupName, cronJobAPIGroups...)
+	if err != nil {
+		return err
+	}
+	if apiGroup == none {
+		return cronJobNotSupportedError
+	}
+
+	cronJob, err := readCronJob(kp, namespace, cronJobName)
+	if err != nil {
+		return err
+	}
+
+	job := jobFromCronJob(cronJob, apiGroup)
+
+	log.Printf("[INFO] Creating new job from cron job %s: %#v", cronJobName, job)
+	out, err := conn.BatchV1().Jobs(namespace).Create(job)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted new job: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	if d.Get("wait_for_completion").(bool) {
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForJobCompletionFunc(conn, out.Namespace, out.Name))
+		if err != nil {
+			return workloadWaitError(conn, out.ObjectMeta, "Job", out.Spec.Selector, err)
+		}
+	}
+
+	return resourceKubernetesCronJobRunRead(d, meta)
+}
+
+func resourceKubernetesCronJobRunRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading job %s", name)
+	job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			// Finished jobs are cleaned up by the cron job controller according
+			// to the history limits, the run itself still happened
+			log.Printf("[INFO] Job %s has already been removed", name)
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received job: %#v", job)
+
+	d.Set("job_name", job.Name)
+
+	return nil
+}
+
+func resourceKubernetesCronJobRunDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting job: %#v", name)
+	policy := metav1.DeletePropagationBackground
+	err = conn.BatchV1().Jobs(namespace).Delete(name, &metav1.DeleteOptions{
+		PropagationPolicy: &policy,
+	})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("[INFO] Job %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+// jobFromCronJob instantiates a Job from the job template of the given cron job
+// the same way `kubectl create job --from=cronjob/<name>` does
+func jobFromCronJob(cronJob *v1beta1.CronJob, apiGroup APIGroup) *batchv1.Job {
+	annotations := map[string]string{
+		"cronjob.kubernetes.io/instantiate": "manual",
+	}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-manual-", cronJob.Name),
+			Namespace:    cronJob.Namespace,
+			Labels:       cronJob.Spec.JobTemplate.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: apiGroup.String(),
+					Kind:       "CronJob",
+					Name:       cronJob.Name,
+					UID:        cronJob.UID,
+					Controller: ptrToBool(true),
+				},
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+}
@@ -0,0 +1,165 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/batch/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesCronJobRun_basic(t *testing.T) {
+	var conf1, conf2 batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesCronJobRunDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCronJobRunConfig_basic(name, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_cron_job_run.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_cron_job_run.test", "cron_job_name", name),
+					resource.TestCheckResourceAttr("kubernetes_cron_job_run.test", "triggers.%", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_cron_job_run.test", "job_name"),
+					testAccCheckKubernetesJobSucceeded(&conf1),
+					testAccCheckKubernetesCronJobRunOwner(&conf1, name),
+				),
+			},
+			{
+				Config: testAccKubernetesCronJobRunConfig_basic(name, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_cron_job_run.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_cron_job_run.test", "triggers.revision", "2"),
+					testAccCheckKubernetesJobSucceeded(&conf2),
+					testAccCheckKubernetesJobRecreated(&conf1, &conf2, true),
+				),
+			},
+		},
+	})
+}
+
+func TestJobFromCronJob(t *testing.T) {
+	cronJob := &v1beta1.CronJob{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cache-warmup",
+			Namespace: "web",
+			UID:       "6a1f7f0e-0000-4000-8000-000000000000",
+		},
+		Spec: v1beta1.CronJobSpec{
+			JobTemplate: v1beta1.JobTemplateSpec{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Labels:      map[string]string{"app": "cache"},
+					Annotations: map[string]string{"team": "web"},
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptrToInt32(2),
+				},
+			},
+		},
+	}
+
+	expected := &batchv1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			GenerateName: "cache-warmup-manual-",
+			Namespace:    "web",
+			Labels:       map[string]string{"app": "cache"},
+			Annotations: map[string]string{
+				"cronjob.kubernetes.io/instantiate": "manual",
+				"team":                              "web",
+			},
+			OwnerReferences: []meta_v1.OwnerReference{
+				{
+					APIVersion: "batch/v1beta1",
+					Kind:       "CronJob",
+					Name:       "cache-warmup",
+					UID:        "6a1f7f0e-0000-4000-8000-000000000000",
+					Controller: ptrToBool(true),
+				},
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptrToInt32(2),
+		},
+	}
+
+	job := jobFromCronJob(cronJob, batchV1beta1)
+	if !reflect.DeepEqual(job, expected) {
+		t.Fatalf("Unexpected job.\nExpected: %#v\nGiven:    %#v", expected, job)
+	}
+}
+
+func testAccCheckKubernetesCronJobRunOwner(obj *batchv1.Job, cronJobName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ref := meta_v1.GetControllerOf(obj)
+		if ref == nil || ref.Kind != "CronJob" || ref.Name != cronJobName {
+			return fmt.Errorf("Expected job %s to be controlled by cron job %s, owners: %#v",
+				obj.Name, cronJobName, obj.OwnerReferences)
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesCronJobRunDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_cron_job_run" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.BatchV1().Jobs(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil && resp.DeletionTimestamp == nil {
+			return fmt.Errorf("Job still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesCronJobRunConfig_basic(name, revision string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cron_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		schedule = "@yearly"
+		job_template {
+			spec {
+				backoff_limit = 1
+				template {
+					spec {
+						container {
+							name = "hello"
+							image = "alpine"
+							command = ["echo", "'hello'"]
+						}
+						restart_policy = "Never"
+					}
+				}
+			}
+		}
+	}
+}
+
+resource "kubernetes_cron_job_run" "test" {
+	cron_job_name = "${kubernetes_cron_job.test.metadata.0.name}"
+	triggers {
+		revision = "%s"
+	}
+	wait_for_completion = true
+}`, name, revision)
+}
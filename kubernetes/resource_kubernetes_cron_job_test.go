@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -15,10 +16,11 @@ func TestAccKubernetesCronJob_basic(t *testing.T) {
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: "kubernetes_cron_job.test",
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckKubernetesCronJobDestroy,
+		PreCheck:        func() { testAccPreCheck(t) },
+		IDRefreshName:   "kubernetes_cron_job.test",
+		IDRefreshIgnore: []string{"deletion_propagation"},
+		Providers:       testAccProviders,
+		CheckDestroy:    testAccCheckKubernetesCronJobDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccKubernetesCronJobConfig_basic(name),
@@ -34,6 +36,8 @@ func TestAccKubernetesCronJob_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.job_template.0.spec.0.parallelism", "1"),
 					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.job_template.0.spec.0.backoff_limit", "2"),
 					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.job_template.0.spec.0.template.0.spec.0.container.0.name", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "next_schedule_times.#", "5"),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "active.#", "0"),
 				),
 			},
 			{
@@ -62,10 +66,11 @@ func TestAccKubernetesCronJob_extra(t *testing.T) {
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: "kubernetes_cron_job.test",
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckKubernetesCronJobDestroy,
+		PreCheck:        func() { testAccPreCheck(t) },
+		IDRefreshName:   "kubernetes_cron_job.test",
+		IDRefreshIgnore: []string{"deletion_propagation"},
+		Providers:       testAccProviders,
+		CheckDestroy:    testAccCheckKubernetesCronJobDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccKubernetesCronJobConfig_extra(name),
@@ -97,6 +102,22 @@ func TestAccKubernetesCronJob_extra(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCronJob_invalidSchedule(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesCronJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesCronJobConfig_schedule(name, "1 0 * *"),
+				ExpectError: regexp.MustCompile("is not a valid cron schedule"),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesCronJobDestroy(s *terraform.State) error {
 	kp := testAccProvider.Meta().(*kubernetesProvider)
 
@@ -145,6 +166,31 @@ func testAccCheckKubernetesCronJobExists(n string, obj *v1beta1.CronJob) resourc
 	}
 }
 
+func testAccKubernetesCronJobConfig_schedule(name, schedule string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cron_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		schedule = "%s"
+		job_template {
+			spec {
+				template {
+					spec {
+						container {
+							name = "hello"
+							image = "alpine"
+							command = ["echo", "'hello'"]
+						}
+					}
+				}
+			}
+		}
+	}
+}`, name, schedule)
+}
+
 func testAccKubernetesCronJobConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_cron_job" "test" {
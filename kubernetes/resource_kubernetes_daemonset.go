@@ -15,6 +15,7 @@ import (
 	"k8s.io/api/extensions/v1beta1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const daemonSetResourceGroupName = "daemonsets"
@@ -23,7 +24,7 @@ var daemonSetAPIGroups = []APIGroup{appsV1, appsV1beta2, extensionsV1beta1}
 var daemonSetNotSupportedError = errors.New("could not find Kubernetes API group that supports DaemonSet resources")
 
 func resourceKubernetesDaemonSet() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceKubernetesDaemonSetCreate,
 		Read:   resourceKubernetesDaemonSetRead,
 		Exists: resourceKubernetesDaemonSetExists,
@@ -32,7 +33,7 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 1,
+		SchemaVersion: 2,
 		MigrateState:  resourceKubernetesDaemonSetStateUpgrader,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -43,6 +44,8 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("daemonset", true),
+			"wait_for": waitForSchema(),
+			"status":   daemonSetStatusSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the daemonset. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -57,9 +60,22 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 							Default:     0,
 						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
-							Required:    true,
+							Type:          schema.TypeMap,
+							Description:   "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							Computed:      true,
+							Deprecated:    "Use label_selector instead, it also supports set-based requirements",
+							ConflictsWith: []string{"spec.0.label_selector"},
+						},
+						"label_selector": {
+							Type:          schema.TypeList,
+							Description:   "A label query over pods that are managed by the daemon set, supporting set-based requirements. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"spec.0.selector"},
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(true),
+							},
 						},
 						"strategy": {
 							Type:        schema.TypeList,
@@ -143,6 +159,13 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 			},
 		},
 	}
+
+	// The server-side dry run needs the schema, it's only built once
+	r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesDaemonSetCustomizeDiff(diff, meta, r.Schema)
+	}
+
+	return r
 }
 
 func buildDaemonSetObject(d *schema.ResourceData) (*v1.DaemonSet, error) {
@@ -198,13 +221,20 @@ func resourceKubernetesDaemonSetCreate(d *schema.ResourceData, meta interface{})
 		err = daemonSetNotSupportedError
 	}
 	if err != nil {
-		return fmt.Errorf("Failed to create daemonset: %s", err)
+		return wrapAPIError(err, "Failed to create daemonset")
 	}
 
 	d.SetId(buildId(out.ObjectMeta))
 
 	log.Printf("[INFO] Submitted new daemonset: %#v", out)
 
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "DaemonSet", func() (interface{}, error) {
+		return readDaemonSet(kp, out.GetNamespace(), out.GetName())
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "DaemonSet", out.Spec.Selector, err)
+	}
+
 	return resourceKubernetesDaemonSetRead(d, meta)
 }
 
@@ -240,6 +270,11 @@ func resourceKubernetesDaemonSetRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	err = d.Set("status", flattenDaemonSetStatus(daemonset.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -285,70 +320,102 @@ func resourceKubernetesDaemonSetUpdate(d *schema.ResourceData, meta interface{})
 	conn := kp.conn
 	namespace, name, err := idParts(d.Id())
 
-	daemonset, err := buildDaemonSetObject(d)
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") {
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandDaemonSetSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+		newObj, err := expandDaemonSetSpec(newSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+
+		specOps, err := diffJSONPatch("/spec", oldObj, newObj)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "apps/v1", "DaemonSet", expandDaemonSetObject)
 	if err != nil {
 		return err
 	}
+	log.Printf("[INFO] Updating daemonset %q: %v", name, patch)
+
+	out, err := patchDaemonSet(d, kp, patch)
+	if err != nil {
+		return wrapAPIError(err, "Failed to update daemonset")
+	}
+	log.Printf("[INFO] Submitted updated daemonset: %#v", out)
+
+	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+		waitForDaemonSetReplicasFunc(kp, namespace, name))
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "DaemonSet", out.Spec.Selector, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "DaemonSet", func() (interface{}, error) {
+		return readDaemonSet(kp, namespace, name)
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "DaemonSet", out.Spec.Selector, err)
+	}
+
+	return resourceKubernetesDaemonSetRead(d, meta)
+}
+
+// expandDaemonSetObject expands the daemonset of the given state or plan
+func expandDaemonSetObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandDaemonSetSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DaemonSet{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
+func patchDaemonSet(d *schema.ResourceData, kp *kubernetesProvider, patch *updatePatch) (dset *v1.DaemonSet, err error) {
+	conn := kp.conn
+	dset = &v1.DaemonSet{}
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return nil, err
+	}
 
-	log.Printf("[INFO] Updating daemonset: %q", name)
-	out := &v1.DaemonSet{}
 	apiGroup, err := kp.highestSupportedAPIGroup(daemonSetResourceGroupName, daemonSetAPIGroups...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	switch apiGroup {
 	case appsV1:
-		out, err = conn.AppsV1().DaemonSets(namespace).Update(daemonset)
+		err = patch.apply(conn.AppsV1().RESTClient(), daemonSetResourceGroupName, namespace, name, dset)
 
 	case appsV1beta2:
 		beta := &v1beta2.DaemonSet{}
-		err = Convert(daemonset, beta)
+		err = patch.apply(conn.AppsV1beta2().RESTClient(), daemonSetResourceGroupName, namespace, name, beta)
 		if err != nil {
-			break
-		}
-		betaOut, err2 := conn.AppsV1beta2().DaemonSets(namespace).Update(beta)
-		if err2 != nil {
-			err = err2
-			break
-		}
-		err = Convert(betaOut, out)
-		if err != nil {
-			break
+			return
 		}
+		err = Convert(beta, dset)
 
 	case extensionsV1beta1:
 		beta := &v1beta1.DaemonSet{}
-		err = Convert(daemonset, beta)
-		if err != nil {
-			break
-		}
-
-		betaOut, err2 := conn.ExtensionsV1beta1().DaemonSets(namespace).Update(beta)
+		err = patch.apply(conn.ExtensionsV1beta1().RESTClient(), daemonSetResourceGroupName, namespace, name, beta)
 		if err != nil {
-			err = err2
-			break
-		}
-		err = Convert(betaOut, out)
-		if err != nil {
-			break
+			return
 		}
+		err = Convert(beta, dset)
 
 	default:
 		err = daemonSetNotSupportedError
 	}
 
-	if err != nil {
-		return fmt.Errorf("Failed to update daemonset: %s", err)
-	}
-	log.Printf("[INFO] Submitted updated daemonset: %#v", out)
-
-	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForDaemonSetReplicasFunc(kp, namespace, name))
-	if err != nil {
-		return err
-	}
-
-	return resourceKubernetesDaemonSetRead(d, meta)
+	return
 }
 
 func resourceKubernetesDaemonSetDelete(d *schema.ResourceData, meta interface{}) error {
@@ -414,6 +481,10 @@ func resourceKubernetesDaemonSetStateUpgrader(
 		if err != nil {
 			return is, err
 		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found Kubernetes DaemonSet State v1; migrating to v2")
+		is, err = migrateDaemonSetStateV1toV2(is)
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -485,3 +556,51 @@ func waitForDaemonSetReplicasFunc(kp *kubernetesProvider, ns, name string) resou
 			desiredReplicas, daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled))
 	}
 }
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateDaemonSetStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+func resourceKubernetesDaemonSetCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	err := validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+	if err != nil {
+		return err
+	}
+	spec, err := expandDaemonSetSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of daemon set spec: %s", err)
+		return nil
+	}
+	if len(spec.Selector.MatchLabels) == 0 && len(spec.Selector.MatchExpressions) == 0 {
+		return fmt.Errorf("spec.0.label_selector: a selector is required, set label_selector or selector")
+	}
+	err = validatePodTemplate("spec.0.template.0", spec.Template, spec.Selector, nil, controllerRestartPolicies)
+	if err != nil {
+		return err
+	}
+
+	return dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+		oldSpec, _ := diff.GetChange("spec")
+		oldObj, err := expandDaemonSetSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		return &dryRunRequest{
+			Client:   meta.(*kubernetesProvider).conn.AppsV1().RESTClient(),
+			Resource: daemonSetResourceGroupName,
+			Object: &v1.DaemonSet{
+				ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+				Spec:       spec,
+			},
+			Spec:    spec,
+			OldSpec: oldObj,
+		}, nil
+	})
+}
@@ -24,6 +24,8 @@ func TestAccKubernetesDaemonSet_minimal(t *testing.T) {
 				Config: testAccKubernetesDaemonSetConfig_minimal(name),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesDaemonSetExists("kubernetes_daemonset.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "status.#", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "status.0.observed_generation"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "metadata.0.name", name),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
@@ -16,6 +16,7 @@ import (
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 )
 
@@ -26,7 +27,7 @@ var deploymentsAPIGroups = []APIGroup{appsV1, appsV1beta2, appsV1beta1, extensio
 var deploymentNotSupportedError = errors.New("could not find Kubernetes API group that supports Deployment resources")
 
 func resourceKubernetesDeployment() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceKubernetesDeploymentCreate,
 		Read:   resourceKubernetesDeploymentRead,
 		Exists: resourceKubernetesDeploymentExists,
@@ -35,7 +36,7 @@ func resourceKubernetesDeployment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 2,
+		SchemaVersion: 3,
 		MigrateState:  resourceKubernetesDeploymentStateUpgrader,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -51,6 +52,8 @@ func resourceKubernetesDeployment() *schema.Resource {
 				Optional: true,
 				Removed:  "To better match the Kubernetes API, the name attribute should be configured under the metadata block. Please update your Terraform configuration.",
 			},
+			"wait_for": waitForSchema(),
+			"status":   deploymentStatusSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the deployment. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -89,10 +92,22 @@ func resourceKubernetesDeployment() *schema.Resource {
 							Default:     10,
 						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
-							Optional:    true,
-							Computed:    true,
+							Type:          schema.TypeMap,
+							Description:   "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							Computed:      true,
+							Deprecated:    "Use label_selector instead, it also supports set-based requirements",
+							ConflictsWith: []string{"spec.0.label_selector"},
+						},
+						"label_selector": {
+							Type:          schema.TypeList,
+							Description:   "A label query over pods that should match the Replicas count, supporting set-based requirements. If empty, it is defaulted to the labels present on the Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"spec.0.selector"},
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(true),
+							},
 						},
 						"strategy": {
 							Type:        schema.TypeList,
@@ -176,6 +191,13 @@ func resourceKubernetesDeployment() *schema.Resource {
 			},
 		},
 	}
+
+	// The server-side dry run needs the schema, it's only built once
+	r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesDeploymentCustomizeDiff(diff, meta, r.Schema)
+	}
+
+	return r
 }
 
 func relocatedAttribute(name string) *schema.Schema {
@@ -275,7 +297,7 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 		err = deploymentNotSupportedError
 	}
 	if err != nil {
-		return fmt.Errorf("Failed to create deployment: %s", err)
+		return wrapAPIError(err, "Failed to create deployment")
 	}
 
 	log.Printf("[INFO] Created deployment: %s", outDeploymentV1.ObjectMeta.SelfLink)
@@ -302,7 +324,14 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 		),
 	)
 	if err != nil {
-		return err
+		return workloadWaitError(conn, outDeploymentV1.ObjectMeta, "Deployment", outDeploymentV1.Spec.Selector, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "Deployment", func() (interface{}, error) {
+		return readDeployment(kp, outDeploymentV1.GetNamespace(), outDeploymentV1.GetName())
+	})
+	if err != nil {
+		return workloadWaitError(conn, outDeploymentV1.ObjectMeta, "Deployment", outDeploymentV1.Spec.Selector, err)
 	}
 	// We could wait for all pods to actually reach Ready state
 	// but that means checking each pod status separately (which can be expensive at scale)
@@ -344,6 +373,11 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	err = d.Set("status", flattenDeploymentStatus(deployment.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -354,23 +388,29 @@ func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
 	if d.HasChange("spec") {
-		spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandDeploymentSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+		newObj, err := expandDeploymentSpec(newSpec.([]interface{}))
 		if err != nil {
 			return err
 		}
 
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		specOps, err := diffJSONPatch("/spec", oldObj, newObj)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "apps/v1", "Deployment", expandDeploymentObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating deployment %q: %v", name, string(data))
+	log.Printf("[INFO] Updating deployment %q: %v", name, patch)
 
-	out, err := resourceKubernetesPatchDeployment(d, kp, data)
+	out, err := resourceKubernetesPatchDeployment(d, kp, patch)
 	if err != nil {
 		return err
 	}
@@ -380,13 +420,32 @@ func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}
 	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
 		waitForDeploymentReplicasFunc(kp, namespace, name))
 	if err != nil {
-		return err
+		return workloadWaitError(kp.conn, out.ObjectMeta, "Deployment", out.Spec.Selector, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "Deployment", func() (interface{}, error) {
+		return readDeployment(kp, namespace, name)
+	})
+	if err != nil {
+		return workloadWaitError(kp.conn, out.ObjectMeta, "Deployment", out.Spec.Selector, err)
 	}
 
 	return resourceKubernetesDeploymentRead(d, meta)
 }
 
-func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesProvider, data []byte) (deployment *appsv1.Deployment, err error) {
+// expandDeploymentObject expands the deployment of the given state or plan
+func expandDeploymentObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandDeploymentSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
+func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesProvider, patch *updatePatch) (deployment *appsv1.Deployment, err error) {
 	conn := kp.conn
 	deployment = &appsv1.Deployment{}
 
@@ -398,13 +457,14 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 
 	switch apiGroup {
 	case appsV1:
-		deployment, err = conn.AppsV1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		err = patch.apply(conn.AppsV1().RESTClient(), "deployments", namespace, name, deployment)
 		if err != nil {
-			return
+			return nil, err
 		}
 
 	case appsV1beta2:
-		beta, err := conn.AppsV1beta2().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta := &appsv1beta2.Deployment{}
+		err = patch.apply(conn.AppsV1beta2().RESTClient(), "deployments", namespace, name, beta)
 		if err != nil {
 			return nil, err
 		}
@@ -415,7 +475,8 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 		}
 
 	case appsV1beta1:
-		beta, err := conn.AppsV1beta1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta := &appsv1beta1.Deployment{}
+		err = patch.apply(conn.AppsV1beta1().RESTClient(), "deployments", namespace, name, beta)
 		if err != nil {
 			return nil, err
 		}
@@ -426,7 +487,8 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 		}
 
 	case extensionsV1beta1:
-		beta, err := conn.ExtensionsV1beta1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta := &extensionsv1beta1.Deployment{}
+		err = patch.apply(conn.ExtensionsV1beta1().RESTClient(), "deployments", namespace, name, beta)
 		if err != nil {
 			return nil, err
 		}
@@ -460,7 +522,7 @@ func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
-	_, err = resourceKubernetesPatchDeployment(d, kp, data)
+	out, err := resourceKubernetesPatchDeployment(d, kp, &updatePatch{Type: pkgApi.JSONPatchType, Data: data})
 	if err != nil {
 		return err
 	}
@@ -474,7 +536,7 @@ func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}
 		),
 	)
 	if err != nil {
-		return err
+		return workloadWaitError(conn, out.ObjectMeta, "Deployment", out.Spec.Selector, err)
 	}
 
 	policy := metav1.DeletePropagationForeground
@@ -606,9 +668,20 @@ func resourceKubernetesDeploymentStateUpgrader(
 	case 0:
 		log.Println("[INFO] Found Kubernetes Deployment State v0; migrating to v1")
 		is, err = migrateStateV0toV1(is)
+		if err != nil {
+			return is, err
+		}
+		fallthrough
 	case 1:
 		log.Println("[INFO] Found Kubernetes Deployment State v1; migrating to v2")
 		is, err = migrateStateV1toV2(is)
+		if err != nil {
+			return is, err
+		}
+		fallthrough
+	case 2:
+		log.Println("[INFO] Found Kubernetes Deployment State v2; migrating to v3")
+		is, err = migrateDeploymentStateV2toV3(is)
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -671,3 +744,48 @@ func migrateStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState,
 	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
 	return is, nil
 }
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateDeploymentStateV2toV3(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+func resourceKubernetesDeploymentCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	err := validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+	if err != nil {
+		return err
+	}
+	spec, err := expandDeploymentSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of deployment spec: %s", err)
+		return nil
+	}
+	err = validatePodTemplate("spec.0.template.0", spec.Template, spec.Selector, nil, controllerRestartPolicies)
+	if err != nil {
+		return err
+	}
+
+	return dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+		oldSpec, _ := diff.GetChange("spec")
+		oldObj, err := expandDeploymentSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		return &dryRunRequest{
+			Client:   meta.(*kubernetesProvider).conn.AppsV1().RESTClient(),
+			Resource: deploymentsResourceGroupName,
+			Object: &appsv1.Deployment{
+				ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+				Spec:       spec,
+			},
+			Spec:    spec,
+			OldSpec: oldObj,
+		}, nil
+	})
+}
@@ -27,6 +27,8 @@ func TestAccKubernetesDeployment_minimal(t *testing.T) {
 				Config: testAccKubernetesDeploymentConfig_minimal(name),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "status.#", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "status.0.observed_generation"),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "metadata.0.name", name),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
@@ -339,6 +341,41 @@ func pause() resource.TestCheckFunc {
 	}
 }
 
+func TestAccKubernetesDeployment_with_label_selector(t *testing.T) {
+	var conf appsv1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_deployment.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_withLabelSelector(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_labels.app", "web"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_expressions.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_expressions.0.key", "tier"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_expressions.0.operator", "In"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.label_selector.0.match_expressions.0.values.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.selector.app", "web"),
+				),
+			},
+			{
+				ResourceName:            "kubernetes_deployment.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesDeploymentDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider)
 
@@ -413,6 +450,46 @@ resource "kubernetes_deployment" "test" {
 `, name)
 }
 
+func testAccKubernetesDeploymentConfig_withLabelSelector(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    label_selector {
+      match_labels {
+        app = "web"
+      }
+
+      match_expressions {
+        key      = "tier"
+        operator = "In"
+        values   = ["frontend", "canary"]
+      }
+    }
+
+    template {
+      metadata {
+        labels {
+          app  = "web"
+          tier = "frontend"
+        }
+      }
+
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name)
+}
+
 func testAccKubernetesDeploymentConfig_basic(name string, replicas int) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
@@ -0,0 +1,220 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+func resourceKubernetesEndpoints() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesEndpointsCreate,
+		Read:   resourceKubernetesEndpointsRead,
+		Exists: resourceKubernetesEndpointsExists,
+		Update: resourceKubernetesEndpointsUpdate,
+		Delete: resourceKubernetesEndpointsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("endpoints", false),
+			"subset": {
+				Type:        schema.TypeSet,
+				Description: "Set of addresses and ports that comprise a service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#services-without-selectors",
+				Optional:    true,
+				Elem:        endpointsSubsetSchema(),
+			},
+		},
+	}
+}
+
+func resourceKubernetesEndpointsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	err := validateEndpointsService(conn, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	ep := api.Endpoints{
+		ObjectMeta: metadata,
+		Subsets:    expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
+	}
+	log.Printf("[INFO] Creating new endpoints: %#v", ep)
+	out, err := conn.CoreV1().Endpoints(metadata.Namespace).Create(&ep)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted new endpoints: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointsRead(d, meta)
+}
+
+func resourceKubernetesEndpointsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	managed, err := endpointsManagedByController(conn, namespace, name)
+	if err != nil {
+		return err
+	}
+	if managed {
+		log.Printf("[WARN] Service %s/%s has a selector, removing its endpoints from state "+
+			"as they are now managed by the endpoints controller", namespace, name)
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Reading endpoints %s", name)
+	ep, err := conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received endpoints: %#v", ep)
+	err = d.Set("metadata", flattenMetadata(ep.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("subset", flattenEndpointsSubsets(ep.Subsets))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesEndpointsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("subset") {
+		ops = append(ops, &AddOperation{
+			Path:  "/subsets",
+			Value: expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
+		})
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "Endpoints", expandEndpointsObject)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating endpoints %q: %v", name, patch)
+	out := &api.Endpoints{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "endpoints", namespace, name, out)
+	if err != nil {
+		return wrapAPIError(err, "Failed to update endpoints")
+	}
+	log.Printf("[INFO] Submitted updated endpoints: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointsRead(d, meta)
+}
+
+// expandEndpointsObject expands the endpoints of the given state or plan
+func expandEndpointsObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.Endpoints{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Subsets:    expandEndpointsSubsets(get("subset").(*schema.Set)),
+	}, nil
+}
+
+func resourceKubernetesEndpointsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	managed, err := endpointsManagedByController(conn, namespace, name)
+	if err != nil {
+		return err
+	}
+	if managed {
+		log.Printf("[WARN] Not deleting endpoints %s as they are managed by the endpoints controller", name)
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting endpoints: %#v", name)
+	err = conn.CoreV1().Endpoints(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Endpoints %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesEndpointsExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking endpoints %s", name)
+	_, err = conn.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// validateEndpointsService makes sure the endpoints are named after an
+// existing service whose endpoints aren't managed by the endpoints controller
+func validateEndpointsService(conn *kubernetes.Clientset, namespace, name string) error {
+	if name == "" {
+		return fmt.Errorf("metadata.0.name must be set to the name of the service the endpoints belong to")
+	}
+	svc, err := conn.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Endpoints must be named after the service they belong to, "+
+				"but service %q doesn't exist in namespace %q", name, namespace)
+		}
+		return err
+	}
+	if len(svc.Spec.Selector) > 0 {
+		return fmt.Errorf("Service %q has a selector, its endpoints are managed by the endpoints controller", name)
+	}
+	return nil
+}
+
+// endpointsManagedByController reports whether the service the endpoints
+// belong to has gained a selector, so the endpoints controller took them over
+func endpointsManagedByController(conn *kubernetes.Clientset, namespace, name string) (bool, error) {
+	svc, err := conn.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(svc.Spec.Selector) > 0, nil
+}
@@ -0,0 +1,258 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesEndpoints_basic(t *testing.T) {
+	var conf api.Endpoints
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_endpoints.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesEndpointsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEndpointsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointsExists("kubernetes_endpoints.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "metadata.0.annotations.TestAnnotationOne", "one"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"TestAnnotationOne": "one"}),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_endpoints.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_endpoints.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_endpoints.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_endpoints.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "subset.#", "1"),
+					testAccCheckEndpointsSubsets(&conf, []string{"10.0.0.4"}, []int32{5432}),
+				),
+			},
+			{
+				Config: testAccKubernetesEndpointsConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointsExists("kubernetes_endpoints.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "metadata.0.annotations.%", "0"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{}),
+					resource.TestCheckResourceAttr("kubernetes_endpoints.test", "subset.#", "1"),
+					testAccCheckEndpointsSubsets(&conf, []string{"10.0.0.4", "10.0.0.5"}, []int32{5432}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesEndpoints_importBasic(t *testing.T) {
+	resourceName := "kubernetes_endpoints.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesEndpointsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEndpointsConfig_basic(name),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func TestAccKubernetesEndpoints_serviceWithSelector(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesEndpointsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesEndpointsConfig_serviceWithSelector(name),
+				ExpectError: regexp.MustCompile("managed by the endpoints controller"),
+			},
+		},
+	})
+}
+
+func testAccCheckEndpointsSubsets(ep *api.Endpoints, ips []string, ports []int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(ep.Subsets) != 1 {
+			return fmt.Errorf("Expected 1 subset, got %#v", ep.Subsets)
+		}
+		subset := ep.Subsets[0]
+		if len(subset.Addresses) != len(ips) {
+			return fmt.Errorf("Expected addresses %v, got %#v", ips, subset.Addresses)
+		}
+		for i, ip := range ips {
+			if subset.Addresses[i].IP != ip {
+				return fmt.Errorf("Expected addresses %v, got %#v", ips, subset.Addresses)
+			}
+		}
+		if len(subset.Ports) != len(ports) {
+			return fmt.Errorf("Expected ports %v, got %#v", ports, subset.Ports)
+		}
+		for i, port := range ports {
+			if subset.Ports[i].Port != port {
+				return fmt.Errorf("Expected ports %v, got %#v", ports, subset.Ports)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesEndpointsDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_endpoints" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().Endpoints(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Endpoints still exist: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesEndpointsExists(n string, obj *api.Endpoints) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoreV1().Endpoints(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesEndpointsConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		port {
+			port = 5432
+		}
+	}
+}
+
+resource "kubernetes_endpoints" "test" {
+	metadata {
+		annotations {
+			TestAnnotationOne = "one"
+		}
+		name = "${kubernetes_service.test.metadata.0.name}"
+	}
+	subset {
+		address {
+			ip = "10.0.0.4"
+		}
+		port {
+			port = 5432
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesEndpointsConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		port {
+			port = 5432
+		}
+	}
+}
+
+resource "kubernetes_endpoints" "test" {
+	metadata {
+		name = "${kubernetes_service.test.metadata.0.name}"
+	}
+	subset {
+		address {
+			ip = "10.0.0.4"
+		}
+		address {
+			ip = "10.0.0.5"
+		}
+		port {
+			port = 5432
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesEndpointsConfig_serviceWithSelector(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 5432
+		}
+	}
+}
+
+resource "kubernetes_endpoints" "test" {
+	metadata {
+		name = "${kubernetes_service.test.metadata.0.name}"
+	}
+	subset {
+		address {
+			ip = "10.0.0.4"
+		}
+		port {
+			port = 5432
+		}
+	}
+}`, name)
+}
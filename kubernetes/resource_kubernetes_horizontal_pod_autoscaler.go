@@ -1,14 +1,13 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
@@ -142,14 +141,15 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 		diffOps := patchHorizontalPodAutoscalerSpec("spec.0.", "/spec", d)
 		ops = append(ops, diffOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "autoscaling/v1", "HorizontalPodAutoscaler", expandHorizontalPodAutoscalerObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
-	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, patch)
+	out := &api.HorizontalPodAutoscaler{}
+	err = patch.apply(conn.AutoscalingV1().RESTClient(), "horizontalpodautoscalers", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update horizontal pod autoscaler: %s", err)
+		return wrapAPIError(err, "Failed to update horizontal pod autoscaler")
 	}
 	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -157,6 +157,15 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 	return resourceKubernetesHorizontalPodAutoscalerRead(d, meta)
 }
 
+// expandHorizontalPodAutoscalerObject expands the horizontal pod autoscaler
+// of the given state or plan
+func expandHorizontalPodAutoscalerObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.HorizontalPodAutoscaler{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       expandHorizontalPodAutoscalerSpec(get("spec").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesHorizontalPodAutoscalerDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -2,13 +2,16 @@ package kubernetes
 
 import (
 	"log"
+	"time"
 
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesIngress() *schema.Resource {
@@ -22,6 +25,11 @@ func resourceKubernetesIngress() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("ingress", true),
 			"spec": {
@@ -56,10 +64,16 @@ func resourceKubernetesIngress() *schema.Resource {
 													Description: "Path array of path regex associated with a backend. Incoming urls matching the path are forwarded to the backend.",
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
+															"path": {
+																Type:        schema.TypeString,
+																Description: "Path is an extended POSIX regex as defined by IEEE Std 1003.1, (i.e this follows the egrep/unix syntax, not the perl syntax) matched against the path of an incoming request. Currently it can contain characters disallowed from the conventional \"path\" part of a URL as defined by RFC 3986. Paths must begin with a '/'. If unspecified, the path defaults to a catch all sending traffic to the backend.",
+																Optional:    true,
+															},
 															"path_regex": {
 																Type:        schema.TypeString,
-																Description: "path.regex is an extended POSIX regex as defined by IEEE Std 1003.1, (i.e this follows the egrep/unix syntax, not the perl syntax) matched against the path of an incoming request. Currently it can contain characters disallowed from the conventional \"path\" part of a URL as defined by RFC 3986. Paths must begin with a '/'. If unspecified, the path defaults to a catch all sending traffic to the backend.",
+																Description: "Same as `path`, kept for backwards compatibility.",
 																Optional:    true,
+																Deprecated:  "Use `path` instead",
 															},
 															"backend": backendSpecFields(ruleBackedDescription),
 														},
@@ -94,6 +108,12 @@ func resourceKubernetesIngress() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_load_balancer": {
+				Type:        schema.TypeBool,
+				Description: "Terraform will wait for the load balancer to have at least 1 endpoint before considering the resource created.",
+				Optional:    true,
+				Default:     false,
+			},
 			"load_balancer_ingress": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -115,34 +135,55 @@ func resourceKubernetesIngress() *schema.Resource {
 }
 
 func resourceKubernetesIngressCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetesProvider).conn
+	kp := meta.(*kubernetesProvider)
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	ing := &v1beta1.Ingress{
 		Spec: expandIngressSpec(d.Get("spec").([]interface{})),
 	}
 	ing.ObjectMeta = metadata
+	ingresses, err := kp.ingresses(metadata.Namespace)
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Creating new ingress: %#v", ing)
-	out, err := conn.ExtensionsV1beta1().Ingresses(metadata.Namespace).Create(ing)
+	out, err := ingresses.Create(ing)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted new ingress: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
+	if d.Get("wait_for_load_balancer").(bool) {
+		err = waitForLoadBalancer(kp.conn, out.ObjectMeta, "Ingress", d.Timeout(schema.TimeoutCreate), func() (*api.LoadBalancerStatus, error) {
+			ing, err := ingresses.Get(out.Name, meta_v1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &ing.Status.LoadBalancer, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesIngressRead(d, meta)
 }
 
 func resourceKubernetesIngressRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetesProvider).conn
+	kp := meta.(*kubernetesProvider)
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return err
 	}
 
+	ingresses, err := kp.ingresses(namespace)
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Reading ingress %s", name)
-	ing, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(name, meta_v1.GetOptions{})
+	ing, err := ingresses.Get(name, meta_v1.GetOptions{})
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
@@ -154,6 +195,7 @@ func resourceKubernetesIngressRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	flattened := flattenIngressSpec(ing.Spec)
+	keepIngressPathRegex(d, flattened)
 	log.Printf("[DEBUG] Flattened ingress spec: %#v", flattened)
 	err = d.Set("spec", flattened)
 	if err != nil {
@@ -169,44 +211,83 @@ func resourceKubernetesIngressRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceKubernetesIngressUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetesProvider).conn
+	kp := meta.(*kubernetesProvider)
 
-	namespace, _, err := idParts(d.Id())
+	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	spec := expandIngressSpec(d.Get("spec").([]interface{}))
-
-	if metadata.Namespace == "" {
-		metadata.Namespace = "default"
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		oldSpec, newSpec := d.GetChange("spec")
+		specOps, err := diffJSONPatch("/spec",
+			expandIngressSpec(oldSpec.([]interface{})),
+			expandIngressSpec(newSpec.([]interface{})))
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
 
-	ingress := &v1beta1.Ingress{
-		ObjectMeta: metadata,
-		Spec:       spec,
+	// The object is applied in the group-version ingresses are served from
+	apiGroup, err := kp.highestSupportedAPIGroup(ingressesResourceGroupName, ingressesAPIGroups...)
+	if err != nil {
+		return err
 	}
-
-	out, err := conn.ExtensionsV1beta1().Ingresses(namespace).Update(ingress)
+	patch, err := buildUpdatePatch(d, meta, ops, apiGroup.String(), "Ingress", expandIngressObject)
 	if err != nil {
-		return fmt.Errorf("Failed to update ingress: %s", err)
+		return err
+	}
+	log.Printf("[INFO] Updating ingress %q: %v", name, patch)
+	out, err := kp.patchIngress(apiGroup, namespace, name, patch)
+	if err != nil {
+		return wrapAPIError(err, "Failed to update ingress")
 	}
 	log.Printf("[INFO] Submitted updated ingress: %#v", out)
 
+	if d.Get("wait_for_load_balancer").(bool) {
+		ingresses, err := kp.ingresses(namespace)
+		if err != nil {
+			return err
+		}
+		err = waitForLoadBalancer(kp.conn, out.ObjectMeta, "Ingress", d.Timeout(schema.TimeoutUpdate), func() (*api.LoadBalancerStatus, error) {
+			ing, err := ingresses.Get(out.Name, meta_v1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &ing.Status.LoadBalancer, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesIngressRead(d, meta)
 }
 
+// expandIngressObject expands the ingress of the given state or plan
+func expandIngressObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &v1beta1.Ingress{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       expandIngressSpec(get("spec").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesIngressDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*kubernetesProvider).conn
+	kp := meta.(*kubernetesProvider)
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return err
 	}
 
+	ingresses, err := kp.ingresses(namespace)
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Deleting ingress: %#v", name)
-	err = conn.ExtensionsV1beta1().Ingresses(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = ingresses.Delete(name, &meta_v1.DeleteOptions{})
 	if err != nil {
 		return err
 	}
@@ -218,15 +299,19 @@ func resourceKubernetesIngressDelete(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceKubernetesIngressExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*kubernetesProvider).conn
+	kp := meta.(*kubernetesProvider)
 
 	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return false, err
 	}
 
+	ingresses, err := kp.ingresses(namespace)
+	if err != nil {
+		return false, err
+	}
 	log.Printf("[INFO] Checking ingress %s", name)
-	_, err = conn.ExtensionsV1beta1().Ingresses(namespace).Get(name, meta_v1.GetOptions{})
+	_, err = ingresses.Get(name, meta_v1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
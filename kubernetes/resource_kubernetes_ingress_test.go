@@ -34,6 +34,8 @@ func TestAccKubernetesIngress_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.backend.#", "1"),
 					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.backend.0.service_name", "app1"),
 					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.backend.0.service_port", "443"),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.rule.0.http.0.path.0.path", "/.*"),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.rule.0.http.0.path.0.backend.0.service_port", "80"),
 				),
 			},
 			{
@@ -141,8 +143,55 @@ func TestAccKubernetesIngress_InternalKey(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesIngress_namedPortAndPathRegex(t *testing.T) {
+	var conf api.Ingress
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_ingress.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesIngressDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesIngressConfig_namedPortAndPathRegex(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesIngressExists("kubernetes_ingress.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.backend.0.service_port", "http"),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.rule.0.http.0.path.0.path_regex", "/api"),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.rule.0.http.0.path.0.path", ""),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "spec.0.rule.0.http.0.path.0.backend.0.service_port", "grpc"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesIngress_waitForLoadBalancer(t *testing.T) {
+	var conf api.Ingress
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t); skipIfNoGoogleCloudSettingsFound(t) },
+		IDRefreshName: "kubernetes_ingress.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesIngressDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesIngressConfig_waitForLoadBalancer(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesIngressExists("kubernetes_ingress.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "wait_for_load_balancer", "true"),
+					resource.TestCheckResourceAttr("kubernetes_ingress.test", "load_balancer_ingress.#", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_ingress.test", "load_balancer_ingress.0.ip"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesIngressDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+	kp := testAccProvider.Meta().(*kubernetesProvider)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "kubernetes_ingress" {
@@ -154,7 +203,11 @@ func testAccCheckKubernetesIngressDestroy(s *terraform.State) error {
 			return err
 		}
 
-		resp, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(name, meta_v1.GetOptions{})
+		ingresses, err := kp.ingresses(namespace)
+		if err != nil {
+			return err
+		}
+		resp, err := ingresses.Get(name, meta_v1.GetOptions{})
 		if err == nil {
 			if resp.Name == rs.Primary.ID {
 				return fmt.Errorf("Ingress still exists: %s", rs.Primary.ID)
@@ -172,14 +225,18 @@ func testAccCheckKubernetesIngressExists(n string, obj *api.Ingress) resource.Te
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		kp := testAccProvider.Meta().(*kubernetesProvider)
 
 		namespace, name, err := idParts(rs.Primary.ID)
 		if err != nil {
 			return err
 		}
 
-		out, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(name, meta_v1.GetOptions{})
+		ingresses, err := kp.ingresses(namespace)
+		if err != nil {
+			return err
+		}
+		out, err := ingresses.Get(name, meta_v1.GetOptions{})
 		if err != nil {
 			return err
 		}
@@ -208,7 +265,7 @@ resource "kubernetes_ingress" "test" {
 						service_name = "app2"
 						service_port = 80
 					}
-					path_regex = "/.*"
+					path = "/.*"
 				}
 			}
 		}
@@ -324,3 +381,62 @@ resource "kubernetes_ingress" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesIngressConfig_namedPortAndPathRegex(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_ingress" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		backend {
+			service_name = "app1"
+			service_port = "http"
+		}
+		rule {
+			host = "server.domain.com"
+			http {
+				path {
+					backend {
+						service_name = "app2"
+						service_port = "grpc"
+					}
+					path_regex = "/api"
+				}
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesIngressConfig_waitForLoadBalancer(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		type = "NodePort"
+		selector {
+			app = "%s"
+		}
+		port {
+			port = 80
+			target_port = 8080
+		}
+	}
+}
+
+resource "kubernetes_ingress" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		backend {
+			service_name = "${kubernetes_service.test.metadata.0.name}"
+			service_port = 80
+		}
+	}
+	wait_for_load_balancer = true
+}`, name, name, name)
+}
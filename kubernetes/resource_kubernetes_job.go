@@ -7,37 +7,64 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	batchv1 "k8s.io/api/batch/v1"
+	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
+// Pod template, selector and completions of a Job cannot be changed once created
+var jobSpecImmutableFields = []string{"completions", "manual_selector", "selector", "template"}
+
 func resourceKubernetesJob() *schema.Resource {
 	s := &schema.Resource{
 		Create: resourceKubernetesJobCreate,
 		Read:   resourceKubernetesJobRead,
-		// Update: resourceKubernetesJobUpdate,
+		Update: resourceKubernetesJobUpdate,
 		Delete: resourceKubernetesJobDelete,
 		Exists: resourceKubernetesJobExists,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceKubernetesJobStateUpgrader,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("job", true),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the job owned by the cluster",
 				Required:    true,
-				ForceNew:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
 					Schema: jobSpecFields(),
 				},
 			},
+			"deletion_propagation": deletionPropagationSchema(),
+			"wait_for":             waitForSchema(),
+			"status":               jobStatusSchema(),
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the job to complete after create (and update). The apply fails if the job fails, the error includes warning events and logs of the failed containers.",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 
+	// The server-side dry run needs the schema, it's only built once
+	s.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesJobCustomizeDiff(diff, meta, s.Schema)
+	}
+
 	return s
 }
 
@@ -65,6 +92,21 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	if d.Get("wait_for_completion").(bool) {
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForJobCompletionFunc(conn, out.Namespace, out.Name))
+		if err != nil {
+			return workloadWaitError(conn, out.ObjectMeta, "Job", out.Spec.Selector, err)
+		}
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "Job", func() (interface{}, error) {
+		return conn.BatchV1().Jobs(out.Namespace).Get(out.Name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "Job", out.Spec.Selector, err)
+	}
+
 	return resourceKubernetesJobRead(d, meta)
 }
 
@@ -76,55 +118,78 @@ func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if !d.HasChange("metadata") && !d.HasChange("spec") {
+		// Only settings of the provider such as wait_for changed, there's
+		// nothing left to patch on a job removed by the TTL controller
+		if _, ok := d.GetOk("spec.0.ttl_seconds_after_finished"); ok {
+			removed, err := jobRemovedByTTL(conn, d.Id())
+			if err != nil {
+				return err
+			}
+			if removed {
+				log.Printf("[INFO] Job %s has been removed by the TTL controller, skipping update", name)
+				return nil
+			}
+		}
+	}
+
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
 	if d.HasChange("spec") {
-		// specOps, err := patchJobSpec("/spec", "spec.0.", d)
-		// if err != nil {
-		// 	return err
-		// }
-		// ops = append(ops, specOps...)
-
-		spec, err := expandJobSpec(d.Get("spec").([]interface{}))
+		// Changes of immutable fields force a new resource in CustomizeDiff,
+		// the rest can be patched in place
+		specOps, err := patchJobSpec("/spec", "spec.0.", d)
 		if err != nil {
 			return err
 		}
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		ops = append(ops, specOps...)
 	}
 
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "batch/v1", "Job", expandJobObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	// metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	// spec, err := expandJobSpec(d.Get("spec").([]interface{}))
-	// if err != nil {
-	// 	return err
-	// }
-	// spec.Template.ObjectMeta.Annotations = metadata.Annotations
-
-	// job := batchv1.Job{
-	// 	ObjectMeta: metadata,
-	// 	Spec:       spec,
-	// }
+	log.Printf("[INFO] Updating job %s: %v", d.Id(), patch)
 
-	log.Printf("[INFO] Updating job %s: %#v", d.Id(), ops)
-
-	out, err := conn.BatchV1().Jobs(namespace).Patch(name, pkgApi.JSONPatchType, data)
-	// out, err := conn.BatchV1().Jobs(namespace).Update(&job)
+	out := &batchv1.Job{}
+	err = patch.apply(conn.BatchV1().RESTClient(), "jobs", namespace, name, out)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted updated job: %#v", out)
 
+	if d.Get("wait_for_completion").(bool) {
+		err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForJobCompletionFunc(conn, namespace, name))
+		if err != nil {
+			return workloadWaitError(conn, out.ObjectMeta, "Job", out.Spec.Selector, err)
+		}
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "Job", func() (interface{}, error) {
+		return conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "Job", out.Spec.Selector, err)
+	}
+
 	d.SetId(buildId(out.ObjectMeta))
 	return resourceKubernetesJobRead(d, meta)
 }
 
+// expandJobObject expands the job of the given state or plan
+func expandJobObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandJobSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &batchv1.Job{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -136,6 +201,16 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Reading job %s", name)
 	job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			if _, ok := d.GetOk("spec.0.ttl_seconds_after_finished"); ok {
+				// Keep the last known state so the finished job isn't run again
+				log.Printf("[INFO] Job %s has been removed by the TTL controller", name)
+				return nil
+			}
+			log.Printf("[WARN] Job %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
@@ -180,6 +255,11 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	err = d.Set("status", flattenJobStatus(job.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -192,8 +272,13 @@ func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[INFO] Deleting job: %#v", name)
-	err = conn.BatchV1().Jobs(namespace).Delete(name, nil)
+	err = conn.BatchV1().Jobs(namespace).Delete(name, expandDeleteOptions(d))
 	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			log.Printf("[INFO] Job %s already deleted", name)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -231,9 +316,167 @@ func resourceKubernetesJobExists(d *schema.ResourceData, meta interface{}) (bool
 	_, err = conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			if _, ok := d.GetOk("spec.0.ttl_seconds_after_finished"); ok {
+				log.Printf("[INFO] Job %s has been removed by the TTL controller", name)
+				return true, nil
+			}
 			return false, nil
 		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 	}
 	return true, err
 }
+
+func resourceKubernetesJobCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	err := validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+	if err != nil {
+		return err
+	}
+	spec, err := expandJobSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of job spec: %s", err)
+	} else {
+		err := validateJobSpec("spec.0", spec)
+		if err != nil {
+			return err
+		}
+
+		// Updates only patch the few mutable fields of a job, only creation is checked
+		err = dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+			return &dryRunRequest{
+				Client:   meta.(*kubernetesProvider).conn.BatchV1().RESTClient(),
+				Resource: "jobs",
+				Object: &batchv1.Job{
+					ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+					Spec:       spec,
+				},
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// A finished job removed by the TTL controller can't be patched anymore,
+	// any change to it needs a new job
+	if _, ok := diff.GetOk("spec.0.ttl_seconds_after_finished"); ok {
+		removed, err := jobRemovedByTTL(meta.(*kubernetesProvider).conn, diff.Id())
+		if err != nil {
+			return err
+		}
+		if removed {
+			for _, key := range []string{"metadata", "spec"} {
+				if !diff.HasChange(key) {
+					continue
+				}
+				log.Printf("[DEBUG] Job %s has been removed by the TTL controller, forcing new resource", diff.Id())
+				err := diff.ForceNew(key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	for _, f := range jobSpecImmutableFields {
+		key := "spec.0." + f
+		if diff.HasChange(key) {
+			log.Printf("[DEBUG] Job %s: %s cannot be updated, forcing new resource", diff.Id(), key)
+			err := diff.ForceNew(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateJobSpec validates the pod template of a job, the selector
+// is generated by the server unless manual_selector is set
+func validateJobSpec(prefix string, spec batchv1.JobSpec) error {
+	var selector *metav1.LabelSelector
+	if spec.ManualSelector != nil && *spec.ManualSelector {
+		selector = spec.Selector
+	}
+	return validatePodTemplate(prefix+".template.0", spec.Template, selector, nil, jobRestartPolicies)
+}
+
+func jobRemovedByTTL(conn *kubernetes.Clientset, id string) (bool, error) {
+	namespace, name, err := idParts(id)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+func waitForJobCompletionFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		job, err := conn.BatchV1().Jobs(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		for _, c := range job.Status.Conditions {
+			if c.Status != api.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case batchv1.JobComplete:
+				log.Printf("[DEBUG] Job %q completed", name)
+				return nil
+			case batchv1.JobFailed:
+				return resource.NonRetryableError(fmt.Errorf("Job %q failed: %s: %s",
+					name, c.Reason, c.Message))
+			}
+		}
+
+		return resource.RetryableError(fmt.Errorf("Waiting for job %q to complete (active: %d, succeeded: %d, failed: %d)",
+			name, job.Status.Active, job.Status.Succeeded, job.Status.Failed))
+	}
+}
+
+func resourceKubernetesJobStateUpgrader(
+	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	var err error
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Kubernetes Job State v0; migrating to v1")
+		is, err = migrateJobStateV0toV1(is)
+
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+
+	return is, err
+}
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateJobStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
@@ -2,7 +2,9 @@ package kubernetes
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -16,10 +18,11 @@ func TestAccKubernetesJob_basic(t *testing.T) {
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: "kubernetes_job.test",
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckKubernetesJobDestroy,
+		PreCheck:        func() { testAccPreCheck(t) },
+		IDRefreshName:   "kubernetes_job.test",
+		IDRefreshIgnore: []string{"deletion_propagation", "wait_for_completion"},
+		Providers:       testAccProviders,
+		CheckDestroy:    testAccCheckKubernetesJobDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccKubernetesJobConfig_basic(name),
@@ -53,6 +56,161 @@ func TestAccKubernetesJob_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesJob_update(t *testing.T) {
+	var conf1, conf2, conf3 api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_update(name, 1, 60, "alpine"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf1),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.parallelism", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.active_deadline_seconds", "60"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_update(name, 2, 120, "alpine"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf2),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.parallelism", "2"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.active_deadline_seconds", "120"),
+					testAccCheckKubernetesJobRecreated(&conf1, &conf2, false),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_update(name, 2, 120, "busybox"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf3),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.spec.0.container.0.image", "busybox"),
+					testAccCheckKubernetesJobRecreated(&conf2, &conf3, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_ttl_seconds_after_finished(t *testing.T) {
+	var conf api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_ttlSecondsAfterFinished(name, "5", "Foreground"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.ttl_seconds_after_finished", "5"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "deletion_propagation", "Foreground"),
+				),
+			},
+			{
+				// The job has been removed by the TTL controller in the meantime
+				PreConfig: func() { time.Sleep(30 * time.Second) },
+				Config:    testAccKubernetesJobConfig_ttlSecondsAfterFinished(name, "5", "Foreground"),
+				PlanOnly:  true,
+			},
+			{
+				// Settings of the provider can still be changed
+				Config: testAccKubernetesJobConfig_ttlSecondsAfterFinished(name, "5", "Background"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.ttl_seconds_after_finished", "5"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "deletion_propagation", "Background"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_ttlSecondsAfterFinished(name, "0", "Background"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.ttl_seconds_after_finished", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_wait_for_completion(t *testing.T) {
+	var conf api.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_waitForCompletion(name, `["sh", "-c", "echo done"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "wait_for_completion", "true"),
+					testAccCheckKubernetesJobSucceeded(&conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.succeeded", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.conditions.0.type", "Complete"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "status.0.completion_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_wait_for_completion_failed(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobConfig_waitForCompletion(name, `["sh", "-c", "echo migration broke; exit 3"]`),
+				ExpectError: regexp.MustCompile("migration broke"),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_invalidTemplate(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobConfig_invalidTemplate(name),
+				ExpectError: regexp.MustCompile(`(?s)restart_policy: "Always" is not supported.*volume "data" is not defined`),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesJobSucceeded(obj *api.Job) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if obj.Status.Succeeded < 1 {
+			return fmt.Errorf("Expected job %s to have succeeded, status: %#v", obj.Name, obj.Status)
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesJobRecreated(before, after *api.Job, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		recreated := before.UID != after.UID
+		if recreated != expected {
+			return fmt.Errorf("Expected job %s recreated to be %t (UID before: %s, after: %s)",
+				after.Name, expected, before.UID, after.UID)
+		}
+		return nil
+	}
+}
+
 func testAccCheckKubernetesJobDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -149,3 +307,98 @@ resource "kubernetes_job" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesJobConfig_update(name string, parallelism, deadline int, image string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		parallelism = %d
+		active_deadline_seconds = %d
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "%s"
+					command = ["sleep", "30"]
+				}
+				restart_policy = "Never"
+			}
+		}
+	}
+}`, name, parallelism, deadline, image)
+}
+
+func testAccKubernetesJobConfig_invalidTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "alpine"
+					command = ["echo", "'hello'"]
+					volume_mount {
+						name = "data"
+						mount_path = "/data"
+					}
+				}
+				restart_policy = "Always"
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesJobConfig_ttlSecondsAfterFinished(name, ttl, deletionPropagation string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		ttl_seconds_after_finished = %s
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "alpine"
+					command = ["echo", "'hello'"]
+				}
+				restart_policy = "Never"
+			}
+		}
+	}
+	deletion_propagation = "%s"
+	wait_for_completion = true
+}`, name, ttl, deletionPropagation)
+}
+
+func testAccKubernetesJobConfig_waitForCompletion(name, command string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		backoff_limit = 1
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "alpine"
+					command = %s
+				}
+				restart_policy = "Never"
+			}
+		}
+	}
+	wait_for_completion = true
+}`, name, command)
+}
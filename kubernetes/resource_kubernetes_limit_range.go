@@ -8,7 +8,7 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesLimitRange() *schema.Resource {
@@ -38,30 +38,45 @@ func resourceKubernetesLimitRange() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"default": {
-										Type:        schema.TypeMap,
-										Description: "Default resource requirement limit value by resource name if resource limit is omitted.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Default resource requirement limit value by resource name if resource limit is omitted.",
+										Optional:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										ValidateFunc:     validateResourceList,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"default_request": {
-										Type:        schema.TypeMap,
-										Description: "The default resource requirement request value by resource name if resource request is omitted.",
-										Optional:    true,
-										Computed:    true,
+										Type:             schema.TypeMap,
+										Description:      "The default resource requirement request value by resource name if resource request is omitted.",
+										Optional:         true,
+										Computed:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										ValidateFunc:     validateResourceList,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"max": {
-										Type:        schema.TypeMap,
-										Description: "Max usage constraints on this kind by resource name.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Max usage constraints on this kind by resource name.",
+										Optional:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										ValidateFunc:     validateResourceList,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"max_limit_request_ratio": {
-										Type:        schema.TypeMap,
-										Description: "The named resource must have a request and limit that are both non-zero where limit divided by request is less than or equal to the enumerated value; this represents the max burst for the named resource.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "The named resource must have a request and limit that are both non-zero where limit divided by request is less than or equal to the enumerated value; this represents the max burst for the named resource.",
+										Optional:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										ValidateFunc:     validateResourceList,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"min": {
-										Type:        schema.TypeMap,
-										Description: "Min usage constraints on this kind by resource name.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Min usage constraints on this kind by resource name.",
+										Optional:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										ValidateFunc:     validateResourceList,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"type": {
 										Type:        schema.TypeString,
@@ -93,7 +108,7 @@ func resourceKubernetesLimitRangeCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Creating new limit range: %#v", limitRange)
 	out, err := conn.CoreV1().LimitRanges(metadata.Namespace).Create(&limitRange)
 	if err != nil {
-		return fmt.Errorf("Failed to create limit range: %s", err)
+		return wrapAPIError(err, "Failed to create limit range")
 	}
 	log.Printf("[INFO] Submitted new limit range: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -138,23 +153,30 @@ func resourceKubernetesLimitRangeUpdate(d *schema.ResourceData, meta interface{}
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 	if d.HasChange("spec") {
-		spec, err := expandLimitRangeSpec(d.Get("spec").([]interface{}), d.IsNewResource())
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandLimitRangeSpec(oldSpec.([]interface{}), d.IsNewResource())
+		if err != nil {
+			return err
+		}
+		newObj, err := expandLimitRangeSpec(newSpec.([]interface{}), d.IsNewResource())
 		if err != nil {
 			return err
 		}
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		specOps, err := diffJSONPatch("/spec", oldObj, newObj)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "LimitRange", expandLimitRangeObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating limit range %q: %v", name, string(data))
-	out, err := conn.CoreV1().LimitRanges(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating limit range %q: %v", name, patch)
+	out := &api.LimitRange{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "limitranges", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update limit range: %s", err)
+		return wrapAPIError(err, "Failed to update limit range")
 	}
 	log.Printf("[INFO] Submitted updated limit range: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -162,6 +184,18 @@ func resourceKubernetesLimitRangeUpdate(d *schema.ResourceData, meta interface{}
 	return resourceKubernetesLimitRangeRead(d, meta)
 }
 
+// expandLimitRangeObject expands the limit range of the given state or plan
+func expandLimitRangeObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandLimitRangeSpec(get("spec").([]interface{}), false)
+	if err != nil {
+		return nil, err
+	}
+	return &api.LimitRange{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesLimitRangeDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
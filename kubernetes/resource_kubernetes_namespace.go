@@ -10,7 +10,7 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesNamespace() *schema.Resource {
@@ -71,13 +71,14 @@ func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{})
 	conn := meta.(*kubernetesProvider).conn
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "Namespace", expandNamespaceObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	log.Printf("[INFO] Updating namespace: %s", ops)
-	out, err := conn.CoreV1().Namespaces().Patch(d.Id(), pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating namespace: %v", patch)
+	out := &api.Namespace{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "namespaces", "", d.Id(), out)
 	if err != nil {
 		return err
 	}
@@ -87,6 +88,13 @@ func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{})
 	return resourceKubernetesNamespaceRead(d, meta)
 }
 
+// expandNamespaceObject expands the namespace of the given state or plan
+func expandNamespaceObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.Namespace{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -11,7 +11,7 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesPersistentVolume() *schema.Resource {
@@ -26,6 +26,11 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 		},
 
 		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			err := validatePersistentVolumeNodeAffinity(diff)
+			if err != nil {
+				return err
+			}
+
 			if diff.Id() == "" {
 				// We only care about updates, not creation
 				return nil
@@ -78,6 +83,27 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Set:         schema.HashString,
 						},
+						"claim_ref": {
+							Type:        schema.TypeList,
+							Description: "A reference to the persistent volume claim this volume is bound to. Setting it reserves the volume for the given claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes/#binding",
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the persistent volume claim.",
+										Required:    true,
+									},
+									"namespace": {
+										Type:        schema.TypeString,
+										Description: "The namespace of the persistent volume claim.",
+										Optional:    true,
+									},
+								},
+							},
+						},
 						"capacity": {
 							Type:         schema.TypeMap,
 							Description:  "A description of the persistent volume's resources and capacity. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#capacity",
@@ -91,6 +117,27 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 							Optional:    true,
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
+						"node_affinity": {
+							Type:        schema.TypeList,
+							Description: "Constraints that limit what nodes this volume can be accessed from. Required for local volumes.",
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"required": {
+										Type:        schema.TypeList,
+										Description: "The node selector the nodes which can access this volume must match.",
+										Optional:    true,
+										ForceNew:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: nodeSelectorFields(),
+										},
+									},
+								},
+							},
+						},
 						"persistent_volume_reclaim_policy": {
 							Type:        schema.TypeString,
 							Description: "What happens to a persistent volume when released from its claim. Valid options are Retain (default) and Recycle. Recycling must be supported by the volume plugin underlying this persistent volume. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#recycling-policy",
@@ -109,6 +156,14 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 							Description: "A description of the persistent volume's class. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes/#class",
 							Optional:    true,
 						},
+						"volume_mode": {
+							Type:         schema.TypeString,
+							Description:  "Whether the volume is intended to be used with a formatted filesystem or to remain in raw block state. One of Filesystem or Block. Defaults to Filesystem.",
+							Optional:     true,
+							Computed:     true,
+							ForceNew:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Filesystem", "Block"}),
+						},
 					},
 				},
 			},
@@ -116,6 +171,20 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 	}
 }
 
+// validatePersistentVolumeNodeAffinity ensures local volumes are pinned to
+// the nodes their path exists on
+func validatePersistentVolumeNodeAffinity(diff *schema.ResourceDiff) error {
+	local := diff.Get("spec.0.persistent_volume_source.0.local").([]interface{})
+	if len(local) == 0 {
+		return nil
+	}
+	required := diff.Get("spec.0.node_affinity.0.required.0.node_selector_term").([]interface{})
+	if len(required) == 0 {
+		return fmt.Errorf("spec.0.node_affinity.0.required must be set for local persistent volumes")
+	}
+	return nil
+}
+
 func resourceKubernetesPersistentVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -197,13 +266,14 @@ func resourceKubernetesPersistentVolumeUpdate(d *schema.ResourceData, meta inter
 		}
 		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "PersistentVolume", expandPersistentVolumeObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	log.Printf("[INFO] Updating persistent volume %s: %s", d.Id(), ops)
-	out, err := conn.CoreV1().PersistentVolumes().Patch(d.Id(), pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating persistent volume %s: %v", d.Id(), patch)
+	out := &api.PersistentVolume{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "persistentvolumes", "", d.Id(), out)
 	if err != nil {
 		return err
 	}
@@ -213,6 +283,19 @@ func resourceKubernetesPersistentVolumeUpdate(d *schema.ResourceData, meta inter
 	return resourceKubernetesPersistentVolumeRead(d, meta)
 }
 
+// expandPersistentVolumeObject expands the persistent volume of the given
+// state or plan
+func expandPersistentVolumeObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandPersistentVolumeSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &api.PersistentVolume{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesPersistentVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -9,8 +9,10 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
@@ -29,8 +31,11 @@ func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: resourceKubernetesPersistentVolumeClaimCustomizeDiff,
+
 		Schema: persistentVolumeClaimSpecFields(false),
 	}
 }
@@ -139,22 +144,192 @@ func resourceKubernetesPersistentVolumeClaimUpdate(d *schema.ResourceData, meta
 	}
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
-	// The whole spec is ForceNew = nothing to update there
-	data, err := ops.MarshalJSON()
+	// Storage requests are the only part of the spec which can be updated,
+	// CustomizeDiff rejects increases the storage class can't expand
+	var requests api.ResourceList
+	if d.HasChange("spec.0.resources.0.requests") {
+		requests, err = expandMapToResourceList(d.Get("spec.0.resources.0.requests").(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec/resources/requests",
+			Value: requests,
+		})
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "PersistentVolumeClaim", expandPersistentVolumeClaimObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	log.Printf("[INFO] Updating persistent volume claim: %s", ops)
-	out, err := conn.CoreV1().PersistentVolumeClaims(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating persistent volume claim: %v", patch)
+	out := &api.PersistentVolumeClaim{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "persistentvolumeclaims", namespace, name, out)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted updated persistent volume claim: %#v", out)
 
+	if storage, ok := requests[api.ResourceStorage]; ok {
+		err = waitForPersistentVolumeClaimExpansion(conn, out, storage, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesPersistentVolumeClaimRead(d, meta)
 }
 
+// expandPersistentVolumeClaimObject expands the persistent volume claim of
+// the given state or plan
+func expandPersistentVolumeClaimObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandPersistentVolumeClaimSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
+// waitForPersistentVolumeClaimExpansion waits until the volume of the claim
+// has been resized. A pending file system resize is only completed once a pod
+// using the claim is (re)started, so the claim is considered expanded by then.
+func waitForPersistentVolumeClaimExpansion(conn *kubernetes.Clientset, claim *api.PersistentVolumeClaim, storage k8sresource.Quantity, timeout time.Duration) error {
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(claim.Name, meta_v1.GetOptions{})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if persistentVolumeClaimExpanded(out, storage) {
+			return nil
+		}
+		capacity := out.Status.Capacity[api.ResourceStorage]
+		return resource.RetryableError(fmt.Errorf("Persistent volume claim %s has not been expanded to %s yet (capacity: %s)",
+			out.Name, storage.String(), capacity.String()))
+	})
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(conn, claim.ObjectMeta, "PersistentVolumeClaim", 3)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+	}
+	return nil
+}
+
+func persistentVolumeClaimExpanded(claim *api.PersistentVolumeClaim, storage k8sresource.Quantity) bool {
+	for _, c := range claim.Status.Conditions {
+		if c.Type == api.PersistentVolumeClaimFileSystemResizePending && c.Status == api.ConditionTrue {
+			log.Printf("[INFO] Persistent volume claim %s is waiting for a pod to be started to resize its file system", claim.Name)
+			return true
+		}
+	}
+	capacity, ok := claim.Status.Capacity[api.ResourceStorage]
+	return ok && capacity.Cmp(storage) >= 0
+}
+
+func resourceKubernetesPersistentVolumeClaimCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	key := "spec.0.resources.0.requests"
+	if !diff.HasChange(key) {
+		return nil
+	}
+	o, n := diff.GetChange(key)
+	oldRequests, err := expandMapToResourceList(o.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	newRequests, err := expandMapToResourceList(n.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	if resourceListsEqual(oldRequests, newRequests) {
+		// Only spelled differently (e.g. 1Gi and 1024Mi), the diff is suppressed
+		return nil
+	}
+
+	// The storage class is looked up as it is now, enabling its volume
+	// expansion in the same apply isn't seen yet
+	if isPersistentVolumeClaimExpansion(oldRequests, newRequests) {
+		storageClassName := diff.Get("spec.0.storage_class_name").(string)
+		expandable, err := storageClassAllowsVolumeExpansion(meta.(*kubernetesProvider).conn, storageClassName)
+		if err != nil {
+			return err
+		}
+		if expandable {
+			return nil
+		}
+		// Replacing the claim would delete its data, so it has to be asked for
+		if storageClassName == "" {
+			return fmt.Errorf("Persistent volume claim %s has no storage class, its storage request can't be "+
+				"increased in place. Only claims of a storage class with allow_volume_expansion set can be expanded.", diff.Id())
+		}
+		return fmt.Errorf("Storage class %q of persistent volume claim %s doesn't allow volume expansion, "+
+			"its storage request can't be increased in place. Set allow_volume_expansion on the storage class "+
+			"(allowVolumeExpansion) and apply it before increasing the request.", storageClassName, diff.Id())
+	}
+
+	log.Printf("[DEBUG] Persistent volume claim %s: %s cannot be updated, forcing new resource", diff.Id(), key)
+	return diff.ForceNew(key)
+}
+
+// isPersistentVolumeClaimExpansion returns whether only the storage request
+// of a claim increased, the only change of the spec allowed by the API
+func isPersistentVolumeClaimExpansion(oldRequests, newRequests api.ResourceList) bool {
+	if len(oldRequests) != len(newRequests) {
+		return false
+	}
+	for name, newQuantity := range newRequests {
+		oldQuantity, ok := oldRequests[name]
+		if !ok {
+			return false
+		}
+		cmp := newQuantity.Cmp(oldQuantity)
+		if name == api.ResourceStorage {
+			if cmp < 0 {
+				return false
+			}
+			continue
+		}
+		if cmp != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceListsEqual(a, b api.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, quantity := range a {
+		other, ok := b[name]
+		if !ok || quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func storageClassAllowsVolumeExpansion(conn *kubernetes.Clientset, name string) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+	storageClass, err := conn.StorageV1().StorageClasses().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
 func resourceKubernetesPersistentVolumeClaimDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -1,18 +1,192 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/core/v1"
 	storageapi "k8s.io/api/storage/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
+func TestIsPersistentVolumeClaimExpansion(t *testing.T) {
+	cases := []struct {
+		Old      api.ResourceList
+		New      api.ResourceList
+		Expected bool
+	}{
+		{
+			Old:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+			New:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi")},
+			Expected: true,
+		},
+		{
+			Old:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi")},
+			New:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+			Expected: false,
+		},
+		{
+			Old:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("1Gi")},
+			New:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("1024Mi")},
+			Expected: true,
+		},
+		{
+			Old:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+			New:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi"), "example.com/iops": k8sresource.MustParse("1000")},
+			Expected: false,
+		},
+		{
+			Old:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi"), "example.com/iops": k8sresource.MustParse("1000")},
+			New:      api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi"), "example.com/iops": k8sresource.MustParse("2000")},
+			Expected: false,
+		},
+	}
+
+	for i, tc := range cases {
+		if isPersistentVolumeClaimExpansion(tc.Old, tc.New) != tc.Expected {
+			t.Fatalf("%d: expected expansion from %#v to %#v to be %t", i, tc.Old, tc.New, tc.Expected)
+		}
+	}
+}
+
+func TestPersistentVolumeClaimExpanded(t *testing.T) {
+	storage := k8sresource.MustParse("10Gi")
+	cases := []struct {
+		Status   api.PersistentVolumeClaimStatus
+		Expected bool
+	}{
+		{
+			Status: api.PersistentVolumeClaimStatus{
+				Capacity: api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+				Conditions: []api.PersistentVolumeClaimCondition{
+					{Type: api.PersistentVolumeClaimResizing, Status: api.ConditionTrue},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Status: api.PersistentVolumeClaimStatus{
+				Capacity: api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+				Conditions: []api.PersistentVolumeClaimCondition{
+					{Type: api.PersistentVolumeClaimFileSystemResizePending, Status: api.ConditionTrue},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Status: api.PersistentVolumeClaimStatus{
+				Capacity: api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi")},
+			},
+			Expected: true,
+		},
+	}
+
+	for i, tc := range cases {
+		claim := &api.PersistentVolumeClaim{Status: tc.Status}
+		if persistentVolumeClaimExpanded(claim, storage) != tc.Expected {
+			t.Fatalf("%d: expected claim with status %#v to be expanded: %t", i, tc.Status, tc.Expected)
+		}
+	}
+}
+
+func TestPersistentVolumeClaimCustomizeDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/apis/storage.k8s.io/v1/storageclasses/")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(storageapi.StorageClass{
+			TypeMeta:             meta_v1.TypeMeta{Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+			ObjectMeta:           meta_v1.ObjectMeta{Name: name},
+			AllowVolumeExpansion: ptrToBool(name == "expandable"),
+		})
+	}))
+	defer server.Close()
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &kubernetesProvider{conn: conn}
+
+	cases := []struct {
+		Name             string
+		StorageClass     string
+		OldStorage       string
+		NewStorage       string
+		ExpectedError    bool
+		ExpectedForceNew bool
+	}{
+		{"increase", "expandable", "1Gi", "2Gi", false, false},
+		{"shrink", "expandable", "2Gi", "1Gi", false, true},
+		{"same quantity", "standard", "1Gi", "1024Mi", false, false},
+		{"non-expandable class", "standard", "1Gi", "2Gi", true, false},
+	}
+
+	r := resourceKubernetesPersistentVolumeClaim()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "default/foo",
+				Attributes: map[string]string{
+					"metadata.#":                          "1",
+					"metadata.0.name":                     "foo",
+					"metadata.0.namespace":                "default",
+					"spec.#":                              "1",
+					"spec.0.access_modes.#":               "1",
+					"spec.0.access_modes.1254135962":      "ReadWriteOnce",
+					"spec.0.resources.#":                  "1",
+					"spec.0.resources.0.requests.%":       "1",
+					"spec.0.resources.0.requests.storage": tc.OldStorage,
+					"spec.0.storage_class_name":           tc.StorageClass,
+					"spec.0.volume_name":                  "pv-foo",
+					"wait_until_bound":                    "true",
+				},
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "foo"}},
+				"spec": []interface{}{
+					map[string]interface{}{
+						"access_modes": []interface{}{"ReadWriteOnce"},
+						"resources": []interface{}{
+							map[string]interface{}{
+								"requests": map[string]interface{}{"storage": tc.NewStorage},
+							},
+						},
+						"storage_class_name": tc.StorageClass,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError {
+				if err == nil || !strings.Contains(err.Error(), "doesn't allow volume expansion") {
+					t.Fatalf("Expected the expansion to be rejected, given: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.RequiresNew() != tc.ExpectedForceNew {
+				t.Fatalf("Expected the claim to be replaced: %t, given diff: %#v", tc.ExpectedForceNew, diff)
+			}
+		})
+	}
+}
+
 func TestAccKubernetesPersistentVolumeClaim_basic(t *testing.T) {
 	var conf api.PersistentVolumeClaim
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
@@ -444,6 +618,48 @@ func TestAccKubernetesPersistentVolumeClaim_googleCloud_storageClass(t *testing.
 	})
 }
 
+func TestAccKubernetesPersistentVolumeClaim_googleCloud_expansion(t *testing.T) {
+	var pvcConf api.PersistentVolumeClaim
+	var uid string
+
+	className := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	claimName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t); skipIfNoGoogleCloudSettingsFound(t) },
+		IDRefreshName: "kubernetes_persistent_volume_claim.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPersistentVolumeClaimDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_expansion(className, claimName, "5Gi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &pvcConf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allow_volume_expansion", "true"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.resources.0.requests.storage", "5Gi"),
+					func(s *terraform.State) error {
+						uid = string(pvcConf.UID)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_expansion(className, claimName, "10Gi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &pvcConf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.resources.0.requests.storage", "10Gi"),
+					func(s *terraform.State) error {
+						if string(pvcConf.UID) != uid {
+							return fmt.Errorf("Persistent volume claim was recreated, expected UID %q, got %q", uid, pvcConf.UID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesPersistentVolumeClaimDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -921,3 +1137,33 @@ resource "kubernetes_persistent_volume_claim" "test" {
 }
 `, className, className, claimName)
 }
+
+func testAccKubernetesPersistentVolumeClaimConfig_expansion(className, claimName, storage string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_storage_class" "test" {
+	metadata {
+		name = "%s"
+	}
+	storage_provisioner = "kubernetes.io/gce-pd"
+	allow_volume_expansion = true
+	parameters {
+		type = "pd-standard"
+	}
+}
+
+resource "kubernetes_persistent_volume_claim" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		access_modes = ["ReadWriteOnce"]
+		resources {
+			requests {
+				storage = "%s"
+			}
+		}
+		storage_class_name = "${kubernetes_storage_class.test.metadata.0.name}"
+	}
+}
+`, className, claimName, storage)
+}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -337,6 +338,64 @@ func TestAccKubernetesPersistentVolume_hostPath_volumeSource(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPersistentVolume_local(t *testing.T) {
+	var conf api.PersistentVolume
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("tf-acc-test-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_persistent_volume.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPersistentVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesPersistentVolumeConfig_localWithoutNodeAffinity(name),
+				ExpectError: regexp.MustCompile("node_affinity.0.required must be set for local persistent volumes"),
+			},
+			{
+				Config: testAccKubernetesPersistentVolumeConfig_local(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeExists("kubernetes_persistent_volume.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.local.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.local.0.path", "/mnt/disks/ssd1"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.volume_mode", "Block"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.node_affinity.0.required.0.node_selector_term.0.match_expressions.0.key", "kubernetes.io/hostname"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.claim_ref.0.name", "reserved"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.claim_ref.0.namespace", "default"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPersistentVolume_csi(t *testing.T) {
+	var conf api.PersistentVolume
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("tf-acc-test-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_persistent_volume.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPersistentVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPersistentVolumeConfig_csi(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeExists("kubernetes_persistent_volume.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.0.driver", "csi.example.com"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.0.volume_handle", "vol-0123456789"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.0.volume_attributes.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.0.node_publish_secret_ref.0.name", "csi-secret"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume.test", "spec.0.persistent_volume_source.0.csi.0.node_publish_secret_ref.0.namespace", "kube-system"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPersistentVolume_cephFsSecretRef(t *testing.T) {
 	var conf api.PersistentVolume
 	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
@@ -686,6 +745,92 @@ resource "kubernetes_persistent_volume" "test" {
 }`, name, path)
 }
 
+func testAccKubernetesPersistentVolumeConfig_localWithoutNodeAffinity(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_persistent_volume" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		capacity {
+			storage = "10Gi"
+		}
+		access_modes = ["ReadWriteOnce"]
+		persistent_volume_source {
+			local {
+				path = "/mnt/disks/ssd1"
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesPersistentVolumeConfig_local(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_persistent_volume" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		capacity {
+			storage = "10Gi"
+		}
+		access_modes = ["ReadWriteOnce"]
+		volume_mode = "Block"
+		storage_class_name = "local-storage"
+		claim_ref {
+			name = "reserved"
+			namespace = "default"
+		}
+		persistent_volume_source {
+			local {
+				path = "/mnt/disks/ssd1"
+			}
+		}
+		node_affinity {
+			required {
+				node_selector_term {
+					match_expressions {
+						key = "kubernetes.io/hostname"
+						operator = "In"
+						values = ["node-1"]
+					}
+				}
+			}
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesPersistentVolumeConfig_csi(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_persistent_volume" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		capacity {
+			storage = "5Gi"
+		}
+		access_modes = ["ReadWriteOnce"]
+		persistent_volume_source {
+			csi {
+				driver = "csi.example.com"
+				volume_handle = "vol-0123456789"
+				fs_type = "ext4"
+				volume_attributes {
+					"storage.example.com/tier" = "ssd"
+				}
+				node_publish_secret_ref {
+					name = "csi-secret"
+					namespace = "kube-system"
+				}
+			}
+		}
+	}
+}`, name)
+}
+
 func testAccKubernetesPersistentVolumeConfig_cephFsSecretRef(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_persistent_volume" "test" {
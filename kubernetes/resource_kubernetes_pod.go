@@ -8,14 +8,15 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesPod() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceKubernetesPodCreate,
 		Read:   resourceKubernetesPodRead,
 		Update: resourceKubernetesPodUpdate,
@@ -24,8 +25,17 @@ func resourceKubernetesPod() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceKubernetesPodStateUpgrader,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("pod", true),
+			"wait_for": waitForSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the pod owned by the cluster",
@@ -37,6 +47,13 @@ func resourceKubernetesPod() *schema.Resource {
 			},
 		},
 	}
+
+	// The server-side dry run needs the schema, it's only built once
+	r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesPodCustomizeDiff(diff, meta, r.Schema)
+	}
+
+	return r
 }
 func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
@@ -65,7 +82,7 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 	stateConf := &resource.StateChangeConf{
 		Target:  []string{"Running"},
 		Pending: []string{"Pending"},
-		Timeout: 5 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
 			out, err := conn.CoreV1().Pods(metadata.Namespace).Get(metadata.Name, metav1.GetOptions{})
 			if err != nil {
@@ -80,14 +97,17 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 	}
 	_, err = stateConf.WaitForState()
 	if err != nil {
-		lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Pod", 3)
-		if wErr != nil {
-			return wErr
-		}
-		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		return workloadWaitError(conn, out.ObjectMeta, "Pod", nil, err)
 	}
 	log.Printf("[INFO] Pod %s created", out.Name)
 
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "Pod", func() (interface{}, error) {
+		return conn.CoreV1().Pods(out.Namespace).Get(out.Name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "Pod", nil, err)
+	}
+
 	return resourceKubernetesPodRead(d, meta)
 }
 
@@ -107,23 +127,43 @@ func resourceKubernetesPodUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "Pod", expandPodObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	log.Printf("[INFO] Updating pod %s: %s", d.Id(), ops)
+	log.Printf("[INFO] Updating pod %s: %v", d.Id(), patch)
 
-	out, err := conn.CoreV1().Pods(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out := &api.Pod{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "pods", namespace, name, out)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted updated pod: %#v", out)
 
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "Pod", func() (interface{}, error) {
+		return conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "Pod", nil, err)
+	}
+
 	d.SetId(buildId(out.ObjectMeta))
 	return resourceKubernetesPodRead(d, meta)
 }
 
+// expandPodObject expands the pod of the given state or plan
+func expandPodObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandPodSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &api.Pod{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -175,7 +215,7 @@ func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	podSpec, err := flattenPodSpec(pod.Spec)
+	podSpec, err := flattenPodSpec(pod.Spec, d, "spec.0.")
 	if err != nil {
 		return err
 	}
@@ -258,3 +298,63 @@ func removeTokenVolumeMount(c api.Container, tokenName string) api.Container {
 	}
 	return c
 }
+
+func resourceKubernetesPodCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	err := validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+	if err != nil {
+		return err
+	}
+	spec, err := expandPodSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of pod spec: %s", err)
+		return nil
+	}
+	err = validatePodSpec("spec.0", spec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// Updates only patch the few mutable fields of a pod, only creation is checked
+	return dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+		return &dryRunRequest{
+			Client:   meta.(*kubernetesProvider).conn.CoreV1().RESTClient(),
+			Resource: "pods",
+			Object: &api.Pod{
+				ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+				Spec:       spec,
+			},
+		}, nil
+	})
+}
+
+func resourceKubernetesPodStateUpgrader(
+	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	var err error
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Kubernetes Pod State v0; migrating to v1")
+		is, err = migratePodStateV0toV1(is)
+
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+
+	return is, err
+}
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migratePodStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
@@ -378,10 +378,91 @@ func TestAccKubernetesPod_with_resource_requirements(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
 					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.image", imageName),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.0.memory", "50Mi"),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.0.cpu", "250m"),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.0.memory", "512Mi"),
-					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.0.cpu", "500m"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.memory", "50Mi"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.cpu", "250m"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.memory", "512Mi"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.cpu", "500m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_extended_resources(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithExtendedResources(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.ephemeral-storage", "1Gi"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.ephemeral-storage", "512Mi"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_projected_volume(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithProjectedVolume(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.default_mode", "420"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.#", "3"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.0.config_map.0.name", podName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.1.downward_api.0.items.0.path", "labels"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.2.service_account_token.0.audience", "vault"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.2.service_account_token.0.expiration_seconds", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_host_aliases_and_sysctls(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithHostAliasesAndSysctls(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.host_aliases.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.host_aliases.0.ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.host_aliases.0.hostnames.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.host_aliases.0.hostnames.0", "db.local"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.share_process_namespace", "true"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.readiness_gate.0.condition_type", "www.example.com/feature-1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.security_context.0.run_as_group", "2000"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.security_context.0.sysctl.0.name", "net.core.somaxconn"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.security_context.0.sysctl.0.value", "1024"),
 				),
 			},
 		},
@@ -540,6 +621,30 @@ func TestAccKubernetesPod_with_DNS_config(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_wait_for(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWaitFor(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "wait_for.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "wait_for.0.condition", "Ready"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "wait_for.1.field", "status.phase"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesPodDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -1034,6 +1139,143 @@ resource "kubernetes_pod" "test" {
 	`, podName, imageName)
 }
 
+func testAccKubernetesPodConfigWithExtendedResources(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      resources {
+        limits {
+          memory              = "512Mi"
+          "ephemeral-storage" = "1Gi"
+        }
+
+        requests {
+          "ephemeral-storage" = "512Mi"
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithProjectedVolume(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%[1]s"
+  }
+
+  data {
+    one = "first"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%[1]s"
+  }
+
+  spec {
+    container {
+      image = "%[2]s"
+      name  = "containername"
+
+      volume_mount {
+        mount_path = "/etc/projected"
+        name       = "projected"
+      }
+    }
+
+    volume {
+      name = "projected"
+
+      projected {
+        sources {
+          config_map {
+            name = "${kubernetes_config_map.test.metadata.0.name}"
+          }
+        }
+
+        sources {
+          downward_api {
+            items {
+              path = "labels"
+
+              field_ref {
+                field_path = "metadata.labels"
+              }
+            }
+          }
+        }
+
+        sources {
+          service_account_token {
+            audience           = "vault"
+            expiration_seconds = 7200
+            path               = "token"
+          }
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithHostAliasesAndSysctls(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    host_aliases {
+      ip        = "10.0.0.1"
+      hostnames = ["db.local", "db"]
+    }
+
+    readiness_gate {
+      condition_type = "www.example.com/feature-1"
+    }
+
+    security_context {
+      run_as_group = 2000
+
+      sysctl {
+        name  = "net.core.somaxconn"
+        value = "1024"
+      }
+    }
+
+    share_process_namespace = true
+
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+  }
+}
+`, podName, imageName)
+}
+
 func testAccKubernetesPodConfigWithEmptyDirVolumes1(podName, imageName string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
@@ -1185,3 +1427,29 @@ resource "kubernetes_pod" "test" {
 }
 `, podName, imageName)
 }
+
+func testAccKubernetesPodConfigWaitFor(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+  }
+
+  wait_for {
+    condition = "Ready"
+  }
+
+  wait_for {
+    field = "status.phase"
+    value = "Running"
+  }
+}
+`, podName, imageName)
+}
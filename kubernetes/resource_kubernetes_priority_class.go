@@ -1,14 +1,13 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/scheduling/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesPriorityClass() *schema.Resource {
@@ -90,14 +89,15 @@ func resourceKubernetesPriorityClassUpdate(d *schema.ResourceData, meta interfac
 
 	name := d.Id()
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "scheduling.k8s.io/v1beta1", "PriorityClass", expandPriorityClassObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating priority class %q: %v", name, string(data))
-	out, err := conn.Scheduling().PriorityClasses().Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating priority class %q: %v", name, patch)
+	out := &v1beta1.PriorityClass{}
+	err = patch.apply(conn.Scheduling().RESTClient(), "priorityclasses", "", name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update priority class: %s", err)
+		return wrapAPIError(err, "Failed to update priority class")
 	}
 	log.Printf("[INFO] Submitted updated priority class: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -105,6 +105,16 @@ func resourceKubernetesPriorityClassUpdate(d *schema.ResourceData, meta interfac
 	return resourceKubernetesPriorityClassRead(d, meta)
 }
 
+// expandPriorityClassObject expands the priority class of the given state
+// or plan
+func expandPriorityClassObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &v1beta1.PriorityClass{
+		ObjectMeta:    expandMetadata(get("metadata").([]interface{})),
+		GlobalDefault: get("global_default").(bool),
+		Value:         int32(get("value").(int)),
+	}, nil
+}
+
 func reosurceKubernetesPriorityClassDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
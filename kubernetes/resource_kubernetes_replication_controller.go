@@ -7,23 +7,28 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesReplicationController() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKubernetesReplicationControllerCreate,
-		Read:   resourceKubernetesReplicationControllerRead,
-		Exists: resourceKubernetesReplicationControllerExists,
-		Update: resourceKubernetesReplicationControllerUpdate,
-		Delete: resourceKubernetesReplicationControllerDelete,
+		Create:        resourceKubernetesReplicationControllerCreate,
+		Read:          resourceKubernetesReplicationControllerRead,
+		Exists:        resourceKubernetesReplicationControllerExists,
+		Update:        resourceKubernetesReplicationControllerUpdate,
+		Delete:        resourceKubernetesReplicationControllerDelete,
+		CustomizeDiff: resourceKubernetesReplicationControllerCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceKubernetesReplicationControllerStateUpgrader,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -33,6 +38,8 @@ func resourceKubernetesReplicationController() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("replication controller", true),
+			"wait_for": waitForSchema(),
+			"status":   replicationControllerStatusSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the replication controller. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -91,7 +98,7 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	log.Printf("[INFO] Creating new replication controller: %#v", rc)
 	out, err := conn.CoreV1().ReplicationControllers(metadata.Namespace).Create(&rc)
 	if err != nil {
-		return fmt.Errorf("Failed to create replication controller: %s", err)
+		return wrapAPIError(err, "Failed to create replication controller")
 	}
 
 	d.SetId(buildId(out.ObjectMeta))
@@ -102,7 +109,14 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
 		waitForDesiredReplicasFunc(conn, out.GetNamespace(), out.GetName()))
 	if err != nil {
-		return err
+		return workloadWaitError(conn, out.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: out.Spec.Selector}, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "ReplicationController", func() (interface{}, error) {
+		return conn.CoreV1().ReplicationControllers(out.GetNamespace()).Get(out.GetName(), metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: out.Spec.Selector}, err)
 	}
 	// We could wait for all pods to actually reach Ready state
 	// but that means checking each pod status separately (which can be expensive at scale)
@@ -134,7 +148,7 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 		return err
 	}
 
-	spec, err := flattenReplicationControllerSpec(rc.Spec)
+	spec, err := flattenReplicationControllerSpec(rc.Spec, d)
 	if err != nil {
 		return err
 	}
@@ -144,6 +158,11 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 		return err
 	}
 
+	err = d.Set("status", flattenReplicationControllerStatus(rc.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -158,36 +177,65 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
 	if d.HasChange("spec") {
-		spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandReplicationControllerSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+		newObj, err := expandReplicationControllerSpec(newSpec.([]interface{}))
 		if err != nil {
 			return err
 		}
 
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		specOps, err := diffJSONPatch("/spec", oldObj, newObj)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "ReplicationController", expandReplicationControllerObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating replication controller %q: %v", name, string(data))
-	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating replication controller %q: %v", name, patch)
+	out := &api.ReplicationController{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "replicationcontrollers", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update replication controller: %s", err)
+		return wrapAPIError(err, "Failed to update replication controller")
 	}
 	log.Printf("[INFO] Submitted updated replication controller: %#v", out)
 
 	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
 		waitForDesiredReplicasFunc(conn, namespace, name))
 	if err != nil {
-		return err
+		return workloadWaitError(conn, out.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: out.Spec.Selector}, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "ReplicationController", func() (interface{}, error) {
+		return conn.CoreV1().ReplicationControllers(namespace).Get(name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return workloadWaitError(conn, out.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: out.Spec.Selector}, err)
 	}
 
 	return resourceKubernetesReplicationControllerRead(d, meta)
 }
 
+// expandReplicationControllerObject expands the replication controller of the
+// given state or plan
+func expandReplicationControllerObject(get func(key string) interface{}) (runtime.Object, error) {
+	metadata := expandMetadata(get("metadata").([]interface{}))
+	spec, err := expandReplicationControllerSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	spec.Template.ObjectMeta.Annotations = metadata.Annotations
+	return &api.ReplicationController{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -208,7 +256,7 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 	if err != nil {
 		return err
 	}
-	_, err = conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
 		return err
 	}
@@ -217,7 +265,7 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 	err = resource.Retry(d.Timeout(schema.TimeoutDelete),
 		waitForDesiredReplicasFunc(conn, namespace, name))
 	if err != nil {
-		return err
+		return workloadWaitError(conn, out.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: out.Spec.Selector}, err)
 	}
 
 	err = conn.CoreV1().ReplicationControllers(namespace).Delete(name, &metav1.DeleteOptions{})
@@ -269,3 +317,39 @@ func waitForDesiredReplicasFunc(conn *kubernetes.Clientset, ns, name string) res
 			desiredReplicas, rc.GetName(), rc.Status.FullyLabeledReplicas))
 	}
 }
+
+func resourceKubernetesReplicationControllerStateUpgrader(
+	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	var err error
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Kubernetes ReplicationController State v0; migrating to v1")
+		is, err = migrateReplicationControllerStateV0toV1(is)
+
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+
+	return is, err
+}
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateReplicationControllerStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+func resourceKubernetesReplicationControllerCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	return validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+}
@@ -10,7 +10,7 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesResourceQuota() *schema.Resource {
@@ -34,11 +34,12 @@ func resourceKubernetesResourceQuota() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"hard": {
-							Type:         schema.TypeMap,
-							Description:  "The set of desired hard limits for each named resource. More info: http://releases.k8s.io/HEAD/docs/design/admission_control_resource_quota.md#admissioncontrol-plugin-resourcequota",
-							Optional:     true,
-							Elem:         schema.TypeString,
-							ValidateFunc: validateResourceList,
+							Type:             schema.TypeMap,
+							Description:      "The set of desired hard limits for each named resource. More info: http://releases.k8s.io/HEAD/docs/design/admission_control_resource_quota.md#admissioncontrol-plugin-resourcequota",
+							Optional:         true,
+							Elem:             &schema.Schema{Type: schema.TypeString},
+							ValidateFunc:     validateResourceList,
+							DiffSuppressFunc: suppressEquivalentResourceQuantity,
 						},
 						"scopes": {
 							Type:        schema.TypeSet,
@@ -70,7 +71,7 @@ func resourceKubernetesResourceQuotaCreate(d *schema.ResourceData, meta interfac
 	log.Printf("[INFO] Creating new resource quota: %#v", resQuota)
 	out, err := conn.CoreV1().ResourceQuotas(metadata.Namespace).Create(&resQuota)
 	if err != nil {
-		return fmt.Errorf("Failed to create resource quota: %s", err)
+		return wrapAPIError(err, "Failed to create resource quota")
 	}
 	log.Printf("[INFO] Submitted new resource quota: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -142,25 +143,31 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 	var spec api.ResourceQuotaSpec
 	waitForChangedSpec := false
 	if d.HasChange("spec") {
-		var err error
-		spec, err = expandResourceQuotaSpec(d.Get("spec").([]interface{}))
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandResourceQuotaSpec(oldSpec.([]interface{}))
 		if err != nil {
 			return err
 		}
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		spec, err = expandResourceQuotaSpec(newSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+		specOps, err := diffJSONPatch("/spec", oldObj, spec)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 		waitForChangedSpec = true
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "ResourceQuota", expandResourceQuotaObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating resource quota %q: %v", name, string(data))
-	out, err := conn.CoreV1().ResourceQuotas(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating resource quota %q: %v", name, patch)
+	out := &api.ResourceQuota{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "resourcequotas", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update resource quota: %s", err)
+		return wrapAPIError(err, "Failed to update resource quota")
 	}
 	log.Printf("[INFO] Submitted updated resource quota: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -186,6 +193,18 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 	return resourceKubernetesResourceQuotaRead(d, meta)
 }
 
+// expandResourceQuotaObject expands the resource quota of the given state or plan
+func expandResourceQuotaObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandResourceQuotaSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &api.ResourceQuota{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
 func resourceKubernetesResourceQuotaDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -1,13 +1,13 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesRole() *schema.Resource {
@@ -86,16 +86,22 @@ func resourceKubernetesRoleUpdate(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	cRole := api.Role{
-		ObjectMeta: metadata,
-		Rules:      expandClusterRoleRule(d.Get("rule").([]interface{})),
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("rule") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/rules",
+			Value: expandClusterRoleRule(d.Get("rule").([]interface{})),
+		})
 	}
-
-	log.Printf("[INFO] Updating role %q: %v", name, cRole)
-	out, err := conn.RbacV1().Roles(namespace).Update(&cRole)
+	patch, err := buildUpdatePatch(d, meta, ops, "rbac.authorization.k8s.io/v1", "Role", expandRoleObject)
 	if err != nil {
-		return fmt.Errorf("Failed to update role: %s", err)
+		return err
+	}
+	log.Printf("[INFO] Updating role %q: %v", name, patch)
+	out := &api.Role{}
+	err = patch.apply(conn.RbacV1().RESTClient(), "roles", namespace, name, out)
+	if err != nil {
+		return wrapAPIError(err, "Failed to update role")
 	}
 	log.Printf("[INFO] Submitted updated role: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -103,6 +109,14 @@ func resourceKubernetesRoleUpdate(d *schema.ResourceData, meta interface{}) erro
 	return resourceKubernetesRoleRead(d, meta)
 }
 
+// expandRoleObject expands the role of the given state or plan
+func expandRoleObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.Role{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Rules:      expandClusterRoleRule(get("rule").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesRoleDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -1,13 +1,13 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesRoleBinding() *schema.Resource {
@@ -98,17 +98,29 @@ func resourceKubernetesRoleBindingUpdate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	crb := api.RoleBinding{
-		ObjectMeta: metadata,
-		RoleRef:    expandRoleRef(d.Get("role_ref").([]interface{})[0]),
-		Subjects:   expandSubjects(d.Get("subject").([]interface{})),
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("role_ref") {
+		// The role reference is immutable, the API server rejects the change
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/roleRef",
+			Value: expandRoleRef(d.Get("role_ref").([]interface{})[0]),
+		})
 	}
-
-	log.Printf("[INFO] Updating role binding %q: %v", name, crb)
-	out, err := conn.RbacV1().RoleBindings(namespace).Update(&crb)
+	if d.HasChange("subject") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/subjects",
+			Value: expandSubjects(d.Get("subject").([]interface{})),
+		})
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "rbac.authorization.k8s.io/v1", "RoleBinding", expandRoleBindingObject)
 	if err != nil {
-		return fmt.Errorf("Failed to update role binding: %s", err)
+		return err
+	}
+	log.Printf("[INFO] Updating role binding %q: %v", name, patch)
+	out := &api.RoleBinding{}
+	err = patch.apply(conn.RbacV1().RESTClient(), "rolebindings", namespace, name, out)
+	if err != nil {
+		return wrapAPIError(err, "Failed to update role binding")
 	}
 	log.Printf("[INFO] Submitted updated role binding: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -116,6 +128,15 @@ func resourceKubernetesRoleBindingUpdate(d *schema.ResourceData, meta interface{
 	return resourceKubernetesRoleBindingRead(d, meta)
 }
 
+// expandRoleBindingObject expands the role binding of the given state or plan
+func expandRoleBindingObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.RoleBinding{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		RoleRef:    expandRoleRef(get("role_ref").([]interface{})[0]),
+		Subjects:   expandSubjects(get("subject").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesRoleBindingDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -3,13 +3,11 @@ package kubernetes
 import (
 	"log"
 
-	"fmt"
-
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesSecret() *schema.Resource {
@@ -113,15 +111,16 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 		ops = append(ops, diffOps...)
 	}
 
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "Secret", expandSecretObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
 
-	log.Printf("[INFO] Updating secret %q: %v", name, data)
-	out, err := conn.CoreV1().Secrets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating secret %q: %v", name, patch)
+	out := &api.Secret{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "secrets", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update secret: %s", err)
+		return wrapAPIError(err, "Failed to update secret")
 	}
 
 	log.Printf("[INFO] Submitting updated secret: %#v", out)
@@ -130,6 +129,15 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 	return resourceKubernetesSecretRead(d, meta)
 }
 
+// expandSecretObject expands the secret of the given state or plan
+func expandSecretObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.Secret{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Data:       expandStringMapToByteMap(get("data").(map[string]interface{})),
+		Type:       api.SecretType(get("type").(string)),
+	}, nil
+}
+
 func resourceKubernetesSecretDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
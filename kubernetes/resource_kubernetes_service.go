@@ -10,6 +10,8 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesService() *schema.Resource {
@@ -24,6 +26,12 @@ func resourceKubernetesService() *schema.Resource {
 		},
 		CustomizeDiff: resourceKubernetesServiceCustomizeDiff,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service", true),
 			"spec": {
@@ -167,6 +175,12 @@ func resourceKubernetesService() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_load_balancer": {
+				Type:        schema.TypeBool,
+				Description: "Terraform will wait for the load balancer to have at least 1 endpoint before considering the resource created. Only applies to `type = LoadBalancer`.",
+				Optional:    true,
+				Default:     false,
+			},
 			"load_balancer_ingress": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -203,32 +217,16 @@ func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] Submitted new service: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
-	if out.Spec.Type == api.ServiceTypeLoadBalancer {
-		log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
-
-		err = resource.Retry(10*time.Minute, func() *resource.RetryError {
+	if d.Get("wait_for_load_balancer").(bool) && out.Spec.Type == api.ServiceTypeLoadBalancer {
+		err = waitForLoadBalancer(conn, out.ObjectMeta, "Service", d.Timeout(schema.TimeoutCreate), func() (*api.LoadBalancerStatus, error) {
 			svc, err := conn.CoreV1().Services(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
 			if err != nil {
-				log.Printf("[DEBUG] Received error: %#v", err)
-				return resource.NonRetryableError(err)
-			}
-
-			lbIngress := svc.Status.LoadBalancer.Ingress
-
-			log.Printf("[INFO] Received service status: %#v", svc.Status)
-			if len(lbIngress) > 0 {
-				return nil
+				return nil, err
 			}
-
-			return resource.RetryableError(fmt.Errorf(
-				"Waiting for service %q to assign IP/hostname for a load balancer", d.Id()))
+			return &svc.Status.LoadBalancer, nil
 		})
 		if err != nil {
-			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", 3)
-			if wErr != nil {
-				return wErr
-			}
-			return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+			return err
 		}
 	}
 
@@ -273,33 +271,60 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	namespace, _, err := idParts(d.Id())
+	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	spec := expandServiceSpec(d.Get("spec").([]interface{}))
-
-	if metadata.Namespace == "" {
-		metadata.Namespace = "default"
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		oldSpec, newSpec := d.GetChange("spec")
+		specOps, err := diffJSONPatch("/spec",
+			expandServiceSpec(oldSpec.([]interface{})),
+			expandServiceSpec(newSpec.([]interface{})))
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
-
-	service := &api.Service{
-		ObjectMeta: metadata,
-		Spec:       spec,
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "Service", expandServiceObject)
+	if err != nil {
+		return err
 	}
-
-	out, err := conn.CoreV1().Services(namespace).Update(service)
+	log.Printf("[INFO] Updating service %q: %v", name, patch)
+	out := &api.Service{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "services", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update service: %s", err)
+		return wrapAPIError(err, "Failed to update service")
 	}
 	log.Printf("[INFO] Submitted updated service: %#v", out)
 
 	d.SetId(buildId(out.ObjectMeta))
+
+	if d.Get("wait_for_load_balancer").(bool) && out.Spec.Type == api.ServiceTypeLoadBalancer {
+		err = waitForLoadBalancer(conn, out.ObjectMeta, "Service", d.Timeout(schema.TimeoutUpdate), func() (*api.LoadBalancerStatus, error) {
+			svc, err := conn.CoreV1().Services(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &svc.Status.LoadBalancer, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesServiceRead(d, meta)
 }
 
+// expandServiceObject expands the service of the given state or plan
+func expandServiceObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.Service{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       expandServiceSpec(get("spec").([]interface{})),
+	}, nil
+}
+
 func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -314,6 +339,11 @@ func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	err = waitForServiceLoadBalancerCleanup(conn, namespace, name, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Service %s deleted", name)
 
 	d.SetId("")
@@ -339,6 +369,55 @@ func resourceKubernetesServiceExists(d *schema.ResourceData, meta interface{}) (
 	return true, err
 }
 
+// loadBalancerCleanupFinalizer is set by the service controller on services
+// of type LoadBalancer until the cloud load balancer has been deleted
+const loadBalancerCleanupFinalizer = "service.kubernetes.io/load-balancer-cleanup"
+
+// waitForServiceLoadBalancerCleanup waits for a deleted service to be gone,
+// as the service controller holds it back until the load balancer is deleted
+func waitForServiceLoadBalancerCleanup(conn *kubernetes.Clientset, namespace, name string, timeout time.Duration) error {
+	var svc *api.Service
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return resource.NonRetryableError(err)
+		}
+		svc = out
+
+		if !hasFinalizer(out.ObjectMeta, loadBalancerCleanupFinalizer) {
+			log.Printf("[DEBUG] Service %s has no load balancer to clean up", name)
+			return nil
+		}
+
+		return resource.RetryableError(fmt.Errorf(
+			"Waiting for the load balancer of service %q to be deleted", buildId(out.ObjectMeta)))
+	})
+	if err != nil {
+		if svc == nil {
+			return err
+		}
+		lastWarnings, wErr := getLastWarningsForObject(conn, svc.ObjectMeta, "Service", 3)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+	}
+	return nil
+}
+
+func hasFinalizer(meta meta_v1.ObjectMeta, finalizer string) bool {
+	for _, f := range meta.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceKubernetesServiceCustomizeDiff(df *schema.ResourceDiff, d interface{}) error {
 	svcType := df.Get("spec.0.type")
 	isExternalServiceType := (svcType == api.ServiceTypeLoadBalancer || svcType == api.ServiceTypeNodePort)
@@ -10,7 +10,7 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesServiceAccount() *schema.Resource {
@@ -179,14 +179,15 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 			Value: expandServiceAccountSecrets(v, defaultSecretName),
 		})
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "v1", "ServiceAccount", expandServiceAccountObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating service account %q: %v", name, string(data))
-	out, err := conn.CoreV1().ServiceAccounts(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating service account %q: %v", name, patch)
+	out := &api.ServiceAccount{}
+	err = patch.apply(conn.CoreV1().RESTClient(), "serviceaccounts", namespace, name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update service account: %s", err)
+		return wrapAPIError(err, "Failed to update service account")
 	}
 	log.Printf("[INFO] Submitted updated service account: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -194,6 +195,17 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 	return resourceKubernetesServiceAccountRead(d, meta)
 }
 
+// expandServiceAccountObject expands the service account of the given state
+// or plan, keeping the token secret created by the token controller
+func expandServiceAccountObject(get func(key string) interface{}) (runtime.Object, error) {
+	return &api.ServiceAccount{
+		AutomountServiceAccountToken: ptrToBool(false),
+		ObjectMeta:                   expandMetadata(get("metadata").([]interface{})),
+		ImagePullSecrets:             expandLocalObjectReferenceArray(get("image_pull_secret").(*schema.Set).List()),
+		Secrets:                      expandServiceAccountSecrets(get("secret").(*schema.Set).List(), get("default_secret_name").(string)),
+	}, nil
+}
+
 func resourceKubernetesServiceAccountDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -114,6 +114,8 @@ func TestAccKubernetesService_loadBalancer(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.load_balancer_source_ranges.#", "2"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.load_balancer_source_ranges.138364083", "10.0.0.5/32"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.load_balancer_source_ranges.445311837", "10.0.0.6/32"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "wait_for_load_balancer", "true"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "load_balancer_ingress.#", "1"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.selector.%", "1"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.selector.App", "MyApp"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "ClientIP"),
@@ -587,6 +589,7 @@ resource "kubernetes_service" "test" {
 		}
 		type = "LoadBalancer"
 	}
+	wait_for_load_balancer = true
 }`, name, name)
 }
 
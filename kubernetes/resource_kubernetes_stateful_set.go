@@ -13,6 +13,7 @@ import (
 	"k8s.io/api/apps/v1beta2"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 )
 
@@ -22,7 +23,7 @@ var statefulSetAPIGroups = []APIGroup{appsV1, appsV1beta2, appsV1beta1}
 var statefulSetNotSupportedError = errs.New("could not find Kubernetes API group that supports StatefulSet resources")
 
 func resourceKubernetesStatefulSet() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceKubernetesStatefulSetCreate,
 		Read:   resourceKubernetesStatefulSetRead,
 		Update: resourceKubernetesStatefulSetUpdate,
@@ -31,10 +32,12 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		SchemaVersion: 1,
+		SchemaVersion: 2,
 		MigrateState:  resourceKubernetesStatefulSetStateUpgrader,
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("statefulset", true),
+			"wait_for": waitForSchema(),
+			"status":   statefulSetStatusSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the StatefulSet. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -63,10 +66,24 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 							ForceNew:    true,
 						},
 						"selector": {
-							Type:        schema.TypeMap,
-							Description: "A label query over pods that should match the Replicas count. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
-							Required:    true,
-							ForceNew:    true,
+							Type:          schema.TypeMap,
+							Description:   "A label query over pods that should match the Replicas count. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							Computed:      true,
+							ForceNew:      true,
+							Deprecated:    "Use label_selector instead, it also supports set-based requirements",
+							ConflictsWith: []string{"spec.0.label_selector"},
+						},
+						"label_selector": {
+							Type:          schema.TypeList,
+							Description:   "A label query over pods that should match the Replicas count, supporting set-based requirements. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Optional:      true,
+							ForceNew:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"spec.0.selector"},
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(false),
+							},
 						},
 						"service_name": {
 							Type:        schema.TypeString,
@@ -157,6 +174,13 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 			},
 		},
 	}
+
+	// The server-side dry run needs the schema, it's only built once
+	r.CustomizeDiff = func(diff *schema.ResourceDiff, meta interface{}) error {
+		return resourceKubernetesStatefulSetCustomizeDiff(diff, meta, r.Schema)
+	}
+
+	return r
 }
 
 func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{}) error {
@@ -207,7 +231,7 @@ func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{
 	}
 
 	if err != nil {
-		return fmt.Errorf("Failed to create Stateful Set: %s", err)
+		return wrapAPIError(err, "Failed to create Stateful Set")
 	}
 
 	d.SetId(buildId(outStatefulSetV1.ObjectMeta))
@@ -218,7 +242,14 @@ func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{
 	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
 		waitForStatefulSetReplicasFunc(kp, outStatefulSetV1.GetNamespace(), outStatefulSetV1.GetName()))
 	if err != nil {
-		return err
+		return workloadWaitError(conn, outStatefulSetV1.ObjectMeta, "StatefulSet", outStatefulSetV1.Spec.Selector, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutCreate, "StatefulSet", func() (interface{}, error) {
+		return readStatefulSet(kp, outStatefulSetV1.GetNamespace(), outStatefulSetV1.GetName())
+	})
+	if err != nil {
+		return workloadWaitError(conn, outStatefulSetV1.ObjectMeta, "StatefulSet", outStatefulSetV1.Spec.Selector, err)
 	}
 	// We could wait for all pods to actually reach Ready state
 	// but that means checking each pod status separately (which can be expensive at scale)
@@ -261,6 +292,11 @@ func resourceKubernetesStatefulSetRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	err = d.Set("status", flattenStatefulSetStatus(statefulSet.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -272,25 +308,31 @@ func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
 	if d.HasChange("spec") {
-		spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
+		oldSpec, newSpec := d.GetChange("spec")
+		oldObj, err := expandStatefulSetSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return err
+		}
+		newObj, err := expandStatefulSetSpec(newSpec.([]interface{}))
 		if err != nil {
 			return err
 		}
 
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		specOps, err := diffJSONPatch("/spec", oldObj, newObj)
+		if err != nil {
+			return fmt.Errorf("Failed to generate update operations: %s", err)
+		}
+		ops = append(ops, specOps...)
 	}
-	data, err := ops.MarshalJSON()
+	patch, err := buildUpdatePatch(d, meta, ops, "apps/v1", "StatefulSet", expandStatefulSetObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating statefulSet %q: %v", name, string(data))
+	log.Printf("[INFO] Updating statefulSet %q: %v", name, patch)
 
-	out, err := patchStatefulSet(d, kp, data)
+	out, err := patchStatefulSet(d, kp, patch)
 	if err != nil {
-		return fmt.Errorf("Failed to update statefulSet: %s", err)
+		return wrapAPIError(err, "Failed to update statefulSet")
 	}
 
 	log.Printf("[INFO] Submitted updated statefulSet: %#v", out)
@@ -298,7 +340,14 @@ func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{
 	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
 		waitForStatefulSetReplicasFunc(kp, namespace, name))
 	if err != nil {
-		return err
+		return workloadWaitError(kp.conn, out.ObjectMeta, "StatefulSet", out.Spec.Selector, err)
+	}
+
+	err = waitForObjectConditions(d, schema.TimeoutUpdate, "StatefulSet", func() (interface{}, error) {
+		return readStatefulSet(kp, namespace, name)
+	})
+	if err != nil {
+		return workloadWaitError(kp.conn, out.ObjectMeta, "StatefulSet", out.Spec.Selector, err)
 	}
 
 	return resourceKubernetesStatefulSetRead(d, meta)
@@ -322,7 +371,7 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	_, err = patchStatefulSet(d, kp, data)
+	out, err := patchStatefulSet(d, kp, &updatePatch{Type: pkgApi.JSONPatchType, Data: data})
 	if err != nil {
 		return err
 	}
@@ -331,7 +380,7 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 	err = resource.Retry(d.Timeout(schema.TimeoutDelete),
 		waitForStatefulSetReplicasFunc(kp, namespace, name))
 	if err != nil {
-		return err
+		return workloadWaitError(conn, out.ObjectMeta, "StatefulSet", out.Spec.Selector, err)
 	}
 
 	apiGroup, err := kp.highestSupportedAPIGroup(statefulSetResourceGroupName, statefulSetAPIGroups...)
@@ -378,7 +427,19 @@ func resourceKubernetesStatefulSetExists(d *schema.ResourceData, meta interface{
 	return true, err
 }
 
-func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, data []byte) (ss *v1.StatefulSet, err error) {
+// expandStatefulSetObject expands the statefulSet of the given state or plan
+func expandStatefulSetObject(get func(key string) interface{}) (runtime.Object, error) {
+	spec, err := expandStatefulSetSpec(get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &v1.StatefulSet{
+		ObjectMeta: expandMetadata(get("metadata").([]interface{})),
+		Spec:       spec,
+	}, nil
+}
+
+func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, patch *updatePatch) (ss *v1.StatefulSet, err error) {
 	conn := kp.conn
 	ss = &v1.StatefulSet{}
 	namespace, name, err := idParts(d.Id())
@@ -389,7 +450,7 @@ func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, data []byt
 	}
 	switch apiGroup {
 	case appsV1:
-		ss, err = conn.AppsV1().StatefulSets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		err = patch.apply(conn.AppsV1().RESTClient(), "statefulsets", namespace, name, ss)
 		if err != nil {
 			return
 		}
@@ -397,7 +458,7 @@ func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, data []byt
 	case appsV1beta2:
 		beta := &v1beta2.StatefulSet{}
 
-		beta, err = conn.AppsV1beta2().StatefulSets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		err = patch.apply(conn.AppsV1beta2().RESTClient(), "statefulsets", namespace, name, beta)
 		if err != nil {
 			return
 		}
@@ -407,7 +468,7 @@ func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, data []byt
 	case appsV1beta1:
 		beta := &v1beta1.StatefulSet{}
 
-		beta, err = conn.AppsV1beta1().StatefulSets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		err = patch.apply(conn.AppsV1beta1().RESTClient(), "statefulsets", namespace, name, beta)
 		if err != nil {
 			return
 		}
@@ -491,6 +552,10 @@ func resourceKubernetesStatefulSetStateUpgrader(
 		if err != nil {
 			return is, err
 		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found Kubernetes StatefulSet State schema v1; migrating to v2")
+		is, err = migrateStatefulSetStateV1toV2(is)
 
 	default:
 		return is, fmt.Errorf("Unexpected schema version: %d", v)
@@ -498,3 +563,58 @@ func resourceKubernetesStatefulSetStateUpgrader(
 
 	return is, err
 }
+
+// Container limits and requests used to be blocks of cpu and memory
+// This migration moves them to maps of resource names
+func migrateStatefulSetStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateStateResourceRequirements(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+func resourceKubernetesStatefulSetCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, s map[string]*schema.Schema) error {
+	err := validateWaitForConditions(diff.Get("wait_for").([]interface{}))
+	if err != nil {
+		return err
+	}
+	spec, err := expandStatefulSetSpec(diff.Get("spec").([]interface{}))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping validation of stateful set spec: %s", err)
+		return nil
+	}
+	if len(spec.Selector.MatchLabels) == 0 && len(spec.Selector.MatchExpressions) == 0 {
+		return fmt.Errorf("spec.0.label_selector: a selector is required, set label_selector or selector")
+	}
+
+	// Volume claim templates can be mounted by the containers like volumes
+	claims := make([]string, 0, len(spec.VolumeClaimTemplates))
+	for _, c := range spec.VolumeClaimTemplates {
+		claims = append(claims, c.Name)
+	}
+
+	err = validatePodTemplate("spec.0.template.0", spec.Template, spec.Selector, claims, controllerRestartPolicies)
+	if err != nil {
+		return err
+	}
+
+	return dryRunDiff(diff, meta, s, func() (*dryRunRequest, error) {
+		oldSpec, _ := diff.GetChange("spec")
+		oldObj, err := expandStatefulSetSpec(oldSpec.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		return &dryRunRequest{
+			Client:   meta.(*kubernetesProvider).conn.AppsV1().RESTClient(),
+			Resource: statefulSetResourceGroupName,
+			Object: &v1.StatefulSet{
+				ObjectMeta: expandMetadata(diff.Get("metadata").([]interface{})),
+				Spec:       spec,
+			},
+			Spec:    spec,
+			OldSpec: oldObj,
+		}, nil
+	})
+}
@@ -27,6 +27,8 @@ func TestAccKubernetesStatefulSet_basic(t *testing.T) {
 				Config: testAccKubernetesStatefulSetConfig_basic(statefulSetName, imageName1, "Parallel"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesStatefulSetExists("kubernetes_stateful_set.test", &sset),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "status.#", "1"),
+					resource.TestCheckResourceAttrSet("kubernetes_stateful_set.test", "status.0.observed_generation"),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "metadata.0.name", statefulSetName),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "metadata.0.labels.%", "1"),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "metadata.0.labels.app", "one"),
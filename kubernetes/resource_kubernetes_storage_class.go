@@ -1,7 +1,6 @@
 package kubernetes
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -9,7 +8,7 @@ import (
 	api "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func resourceKubernetesStorageClass() *schema.Resource {
@@ -44,6 +43,64 @@ func resourceKubernetesStorageClass() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"volume_binding_mode": {
+				Type:         schema.TypeString,
+				Description:  "Indicates when volume binding and dynamic provisioning should occur",
+				Optional:     true,
+				Default:      "Immediate",
+				ForceNew:     true,
+				ValidateFunc: validateAttributeValueIsIn([]string{"Immediate", "WaitForFirstConsumer"}),
+			},
+			"allowed_topologies": {
+				Type:        schema.TypeList,
+				Description: "Restrict the node topologies where volumes can be dynamically provisioned",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"match_label_expressions": {
+							Type:        schema.TypeList,
+							Description: "A list of topology selector requirements by labels",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Description: "The label key that the selector applies to",
+										Required:    true,
+									},
+									"values": {
+										Type:        schema.TypeSet,
+										Description: "An array of string values. One value must match the label to be selected",
+										Required:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Set:         schema.HashString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"mount_options": {
+				Type:        schema.TypeSet,
+				Description: "Persistent volumes dynamically provisioned by this storage class are created with these mount options",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"allow_volume_expansion": {
+				Type:        schema.TypeBool,
+				Description: "Indicates whether persistent volume claims of this storage class can be expanded",
+				Optional:    true,
+				Default:     false,
+			},
+			"is_default_class": {
+				Type:        schema.TypeBool,
+				Description: "Marks this storage class as the cluster default, unsetting the mark on any other storage class",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -66,6 +123,27 @@ func resourceKubernetesStorageClassCreate(d *schema.ResourceData, meta interface
 		storageClass.Parameters = expandStringMap(v.(map[string]interface{}))
 	}
 
+	bindingMode := api.VolumeBindingMode(d.Get("volume_binding_mode").(string))
+	storageClass.VolumeBindingMode = &bindingMode
+
+	if v, ok := d.GetOk("allowed_topologies"); ok {
+		storageClass.AllowedTopologies = expandTopologySelectorTerms(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("mount_options"); ok {
+		storageClass.MountOptions = schemaSetToStringArray(v.(*schema.Set))
+	}
+
+	storageClass.AllowVolumeExpansion = ptrToBool(d.Get("allow_volume_expansion").(bool))
+
+	isDefault := d.Get("is_default_class").(bool)
+	if isDefault {
+		if storageClass.Annotations == nil {
+			storageClass.Annotations = make(map[string]string)
+		}
+		storageClass.Annotations[isDefaultStorageClassAnnotation] = "true"
+	}
+
 	log.Printf("[INFO] Creating new storage class: %#v", storageClass)
 	out, err := conn.StorageV1().StorageClasses().Create(&storageClass)
 	if err != nil {
@@ -74,6 +152,13 @@ func resourceKubernetesStorageClassCreate(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] Submitted new storage class: %#v", out)
 	d.SetId(out.Name)
 
+	if isDefault {
+		err = unsetOtherDefaultStorageClasses(conn, out.Name)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesStorageClassRead(d, meta)
 }
 
@@ -95,6 +180,20 @@ func resourceKubernetesStorageClassRead(d *schema.ResourceData, meta interface{}
 	d.Set("reclaim_policy", storageClass.ReclaimPolicy)
 	d.Set("parameters", storageClass.Parameters)
 	d.Set("storage_provisioner", storageClass.Provisioner)
+	if storageClass.VolumeBindingMode != nil {
+		d.Set("volume_binding_mode", string(*storageClass.VolumeBindingMode))
+	}
+	err = d.Set("allowed_topologies", flattenTopologySelectorTerms(storageClass.AllowedTopologies))
+	if err != nil {
+		return err
+	}
+	d.Set("mount_options", newStringSet(schema.HashString, storageClass.MountOptions))
+	if storageClass.AllowVolumeExpansion != nil {
+		d.Set("allow_volume_expansion", *storageClass.AllowVolumeExpansion)
+	} else {
+		d.Set("allow_volume_expansion", false)
+	}
+	d.Set("is_default_class", isDefaultStorageClass(storageClass))
 
 	return nil
 }
@@ -104,21 +203,87 @@ func resourceKubernetesStorageClassUpdate(d *schema.ResourceData, meta interface
 
 	name := d.Id()
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
-	data, err := ops.MarshalJSON()
+	if d.HasChange("mount_options") {
+		v := d.Get("mount_options").(*schema.Set)
+		if v.Len() > 0 {
+			ops = append(ops, &AddOperation{
+				Path:  "/mountOptions",
+				Value: schemaSetToStringArray(v),
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: "/mountOptions",
+			})
+		}
+	}
+	if d.HasChange("allow_volume_expansion") {
+		ops = append(ops, &AddOperation{
+			Path:  "/allowVolumeExpansion",
+			Value: d.Get("allow_volume_expansion").(bool),
+		})
+	}
+	patch, err := buildUpdatePatch(d, meta, ops, "storage.k8s.io/v1", "StorageClass", expandStorageClassObject)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+		return err
 	}
-	log.Printf("[INFO] Updating storage class %q: %v", name, string(data))
-	out, err := conn.StorageV1().StorageClasses().Patch(name, pkgApi.JSONPatchType, data)
+	log.Printf("[INFO] Updating storage class %q: %v", name, patch)
+	out := &api.StorageClass{}
+	err = patch.apply(conn.StorageV1().RESTClient(), "storageclasses", "", name, out)
 	if err != nil {
-		return fmt.Errorf("Failed to update storage class: %s", err)
+		return wrapAPIError(err, "Failed to update storage class")
 	}
 	log.Printf("[INFO] Submitted updated storage class: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
+	// The annotation is managed separately from metadata.annotations as
+	// replacing the whole annotations map above may have dropped it
+	isDefault := d.Get("is_default_class").(bool)
+	if isDefaultStorageClass(out) != isDefault {
+		err = patchDefaultStorageClassAnnotation(conn, name, isDefault)
+		if err != nil {
+			return err
+		}
+	}
+	if isDefault {
+		err = unsetOtherDefaultStorageClasses(conn, name)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesStorageClassRead(d, meta)
 }
 
+// expandStorageClassObject expands the storage class of the given state or
+// plan
+func expandStorageClassObject(get func(key string) interface{}) (runtime.Object, error) {
+	storageClass := &api.StorageClass{
+		ObjectMeta:           expandMetadata(get("metadata").([]interface{})),
+		Provisioner:          get("storage_provisioner").(string),
+		Parameters:           expandStringMap(get("parameters").(map[string]interface{})),
+		AllowVolumeExpansion: ptrToBool(get("allow_volume_expansion").(bool)),
+	}
+	if v := get("reclaim_policy").(string); v != "" {
+		reclaimPolicy := corev1.PersistentVolumeReclaimPolicy(v)
+		storageClass.ReclaimPolicy = &reclaimPolicy
+	}
+	bindingMode := api.VolumeBindingMode(get("volume_binding_mode").(string))
+	storageClass.VolumeBindingMode = &bindingMode
+	if v := get("allowed_topologies").([]interface{}); len(v) > 0 {
+		storageClass.AllowedTopologies = expandTopologySelectorTerms(v)
+	}
+	if v := get("mount_options").(*schema.Set); v.Len() > 0 {
+		storageClass.MountOptions = schemaSetToStringArray(v)
+	}
+	if get("is_default_class").(bool) {
+		if storageClass.Annotations == nil {
+			storageClass.Annotations = make(map[string]string)
+		}
+		storageClass.Annotations[isDefaultStorageClassAnnotation] = "true"
+	}
+	return storageClass, nil
+}
+
 func resourceKubernetesStorageClassDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/storage/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +46,10 @@ func TestAccKubernetesStorageClass_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.%", "1"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.type", "pd-ssd"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "reclaim_policy", "Retain"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "volume_binding_mode", "Immediate"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allow_volume_expansion", "false"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "mount_options.#", "0"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "false"),
 					testAccCheckStorageClassParameters(&conf, map[string]string{"type": "pd-ssd"}),
 				),
 			},
@@ -70,6 +75,9 @@ func TestAccKubernetesStorageClass_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.type", "pd-standard"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.zones", "us-west1-a,us-west1-b"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "reclaim_policy", "Delete"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allow_volume_expansion", "true"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "mount_options.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "mount_options.1822771111", "debug"),
 					testAccCheckStorageClassParameters(&conf, map[string]string{"type": "pd-standard", "zones": "us-west1-a,us-west1-b"}),
 				),
 			},
@@ -172,6 +180,152 @@ func TestAccKubernetesStorageClass_importGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesStorageClass_topology(t *testing.T) {
+	var conf api.StorageClass
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_storage_class.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesStorageClassDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStorageClassConfig_topology(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "volume_binding_mode", "WaitForFirstConsumer"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allowed_topologies.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allowed_topologies.0.match_label_expressions.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allowed_topologies.0.match_label_expressions.0.key", "failure-domain.beta.kubernetes.io/zone"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allowed_topologies.0.match_label_expressions.0.values.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesStorageClass_defaultClass(t *testing.T) {
+	var conf, second api.StorageClass
+	var clusterDefaults []string
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_storage_class.test",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			err := testAccCheckKubernetesStorageClassDestroy(s)
+			// Give the cluster its original default class back
+			conn := testAccProvider.Meta().(*kubernetesProvider).conn
+			for _, n := range clusterDefaults {
+				if rErr := patchDefaultStorageClassAnnotation(conn, n, true); rErr != nil && err == nil {
+					err = rErr
+				}
+			}
+			return err
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStorageClassConfig_defaultClass(name, false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "false"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{}),
+					testAccListDefaultStorageClasses(&clusterDefaults),
+				),
+			},
+			{
+				Config: testAccKubernetesStorageClassConfig_defaultClass(name, true, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "true"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "metadata.0.annotations.%", "0"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{isDefaultStorageClassAnnotation: "true"}),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.second", "is_default_class", "false"),
+				),
+			},
+			{
+				Config: testAccKubernetesStorageClassConfig_defaultClass(name, false, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "false"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{}),
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.second", &second),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.second", "is_default_class", "true"),
+					testAccCheckMetaAnnotations(&second.ObjectMeta, map[string]string{isDefaultStorageClassAnnotation: "true"}),
+				),
+			},
+		},
+	})
+}
+
+func TestTopologySelectorTermsRoundTrip(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"match_label_expressions": []interface{}{
+				map[string]interface{}{
+					"key":    "failure-domain.beta.kubernetes.io/zone",
+					"values": newStringSet(schema.HashString, []string{"us-west1-a", "us-west1-b"}),
+				},
+			},
+		},
+	}
+	terms := expandTopologySelectorTerms(in)
+	if len(terms) != 1 || len(terms[0].MatchLabelExpressions) != 1 {
+		t.Fatalf("Unexpected topology selector terms: %#v", terms)
+	}
+	expr := terms[0].MatchLabelExpressions[0]
+	if expr.Key != "failure-domain.beta.kubernetes.io/zone" || len(expr.Values) != 2 {
+		t.Fatalf("Unexpected label requirement: %#v", expr)
+	}
+
+	out := flattenTopologySelectorTerms(terms)
+	exprs := out[0].(map[string]interface{})["match_label_expressions"].([]interface{})
+	values := exprs[0].(map[string]interface{})["values"].(*schema.Set)
+	if !values.Equal(in[0].(map[string]interface{})["match_label_expressions"].([]interface{})[0].(map[string]interface{})["values"]) {
+		t.Fatalf("Values didn't round trip, got %#v", values.List())
+	}
+}
+
+func TestIsDefaultStorageClass(t *testing.T) {
+	cases := []struct {
+		Annotations map[string]string
+		Expected    bool
+	}{
+		{nil, false},
+		{map[string]string{isDefaultStorageClassAnnotation: "true"}, true},
+		{map[string]string{isDefaultStorageClassAnnotation: "false"}, false},
+		{map[string]string{betaIsDefaultStorageClassAnnotation: "true"}, true},
+		{map[string]string{"TestAnnotationOne": "true"}, false},
+	}
+	for i, tc := range cases {
+		sc := &api.StorageClass{ObjectMeta: meta_v1.ObjectMeta{Annotations: tc.Annotations}}
+		if got := isDefaultStorageClass(sc); got != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, got)
+		}
+	}
+}
+
+// testAccListDefaultStorageClasses records the cluster's own default classes
+// so they can be restored once the test has taken over the annotation
+func testAccListDefaultStorageClasses(names *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		list, err := conn.StorageV1().StorageClasses().List(meta_v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		*names = []string{}
+		for _, sc := range list.Items {
+			if isDefaultStorageClass(&sc) {
+				*names = append(*names, sc.Name)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckStorageClassParameters(m *api.StorageClass, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Parameters) == 0 {
@@ -262,6 +416,8 @@ resource "kubernetes_storage_class" "test" {
 	}
 	storage_provisioner = "kubernetes.io/gce-pd"
 	reclaim_policy = "Delete"
+	allow_volume_expansion = true
+	mount_options = ["debug"]
 	parameters {
 		type = "pd-standard"
 		zones = "us-west1-a,us-west1-b"
@@ -289,3 +445,40 @@ resource "kubernetes_storage_class" "test" {
 	storage_provisioner = "kubernetes.io/gce-pd"
 }`, prefix)
 }
+
+func testAccKubernetesStorageClassConfig_topology(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_storage_class" "test" {
+	metadata {
+		name = "%s"
+	}
+	storage_provisioner = "kubernetes.io/gce-pd"
+	volume_binding_mode = "WaitForFirstConsumer"
+	allowed_topologies {
+		match_label_expressions {
+			key = "failure-domain.beta.kubernetes.io/zone"
+			values = ["us-west1-a", "us-west1-b"]
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesStorageClassConfig_defaultClass(name string, first, second bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_storage_class" "test" {
+	metadata {
+		name = "%s"
+	}
+	storage_provisioner = "kubernetes.io/gce-pd"
+	is_default_class = %t
+}
+
+resource "kubernetes_storage_class" "second" {
+	metadata {
+		name = "%s-second"
+	}
+	storage_provisioner = "kubernetes.io/gce-pd"
+	is_default_class = %t
+	depends_on = ["kubernetes_storage_class.test"]
+}`, name, first, name, second)
+}
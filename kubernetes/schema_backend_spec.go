@@ -19,8 +19,8 @@ func backendSpecFields(description string) *schema.Schema {
 					Optional:    true,
 				},
 				"service_port": {
-					Type:        schema.TypeInt,
-					Description: "Specifies the port of the referenced service.",
+					Type:        schema.TypeString,
+					Description: "Specifies the port of the referenced service. Can be a port number or the name of a port of the service.",
 					Computed:    true,
 					Optional:    true,
 				},
@@ -92,53 +92,22 @@ func handlerFields() map[string]*schema.Schema {
 func resourcesField() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"limits": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			Computed:    true,
-			MaxItems:    1,
-			Description: "Describes the maximum amount of compute resources allowed. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"cpu": {
-						Type:             schema.TypeString,
-						Optional:         true,
-						Computed:         true,
-						ValidateFunc:     validateResourceQuantity,
-						DiffSuppressFunc: suppressEquivalentResourceQuantity,
-					},
-					"memory": {
-						Type:             schema.TypeString,
-						Optional:         true,
-						Computed:         true,
-						ValidateFunc:     validateResourceQuantity,
-						DiffSuppressFunc: suppressEquivalentResourceQuantity,
-					},
-				},
-			},
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Computed:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateFunc:     validateResourceList,
+			DiffSuppressFunc: suppressEquivalentResourceQuantity,
+			Description:      "Describes the maximum amount of compute resources allowed by resource name, e.g. cpu, memory, ephemeral-storage, hugepages-2Mi or extended resources such as nvidia.com/gpu. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
 		},
 		"requests": {
-			Type:     schema.TypeList,
-			Optional: true,
-			Computed: true,
-			MaxItems: 1,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"cpu": {
-						Type:             schema.TypeString,
-						Optional:         true,
-						Computed:         true,
-						ValidateFunc:     validateResourceQuantity,
-						DiffSuppressFunc: suppressEquivalentResourceQuantity,
-					},
-					"memory": {
-						Type:             schema.TypeString,
-						Optional:         true,
-						Computed:         true,
-						ValidateFunc:     validateResourceQuantity,
-						DiffSuppressFunc: suppressEquivalentResourceQuantity,
-					},
-				},
-			},
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Computed:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateFunc:     validateResourceList,
+			DiffSuppressFunc: suppressEquivalentResourceQuantity,
+			Description:      "Describes the minimum amount of compute resources required by resource name. If requests are omitted for a container, they default to the limits if explicitly specified, otherwise to an implementation-defined value. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
 		},
 	}
 }
@@ -175,6 +144,12 @@ func volumeMountFields() map[string]*schema.Schema {
 			Required:    true,
 			Description: "Path within the container at which the volume should be mounted. Must not contain ':'.",
 		},
+		"mount_propagation": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Determines how mounts are propagated from the host to container and the other way around. One of None, HostToContainer or Bidirectional. When not set, None is used.",
+			ValidateFunc: validateAttributeValueIsIn([]string{"None", "HostToContainer", "Bidirectional"}),
+		},
 		"name": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -194,6 +169,21 @@ func volumeMountFields() map[string]*schema.Schema {
 	}
 }
 
+func volumeDeviceFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"device_path": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Path inside of the container that the device will be mapped to.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Must match the name of a persistentVolumeClaim in the pod.",
+		},
+	}
+}
+
 func containerFields(isUpdatable bool) map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
 		"args": {
@@ -513,6 +503,14 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Default:     false,
 			Description: "Whether this container should allocate a TTY for itself",
 		},
+		"volume_device": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Block devices of raw block persistent volume claims to be used by the container.",
+			Elem: &schema.Resource{
+				Schema: volumeDeviceFields(),
+			},
+		},
 		"volume_mount": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -586,18 +584,39 @@ func probeSchema() *schema.Resource {
 
 func securityContextSchema() *schema.Resource {
 	m := map[string]*schema.Schema{
+		"allow_privilege_escalation": {
+			// A string so that false, setting no_new_privs, can be told apart from unset
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateBoolString,
+			StateFunc:    normalizeBoolString,
+			Description:  "Controls whether a process can gain more privileges than its parent process. This bool directly controls if the no_new_privs flag will be set on the container process. It is always true when the container is run as privileged or has CAP_SYS_ADMIN. Left to the API server when unset.",
+		},
 		"privileged": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
 			Description: `Run container in privileged mode. Processes in privileged containers are essentially equivalent to root on the host.`,
 		},
+		"proc_mount": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The type of proc mount to use for the container. One of Default or Unmasked. Defaults to the container runtime defaults for readonly paths and masked paths. Requires the ProcMountType feature gate to be enabled.",
+			ValidateFunc: validateAttributeValueIsIn([]string{"Default", "Unmasked"}),
+		},
 		"read_only_root_filesystem": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
 			Description: "Whether this container has a read-only root filesystem.",
 		},
+		"run_as_group": {
+			Type:        schema.TypeInt,
+			Description: "The GID to run the entrypoint of the container process. Uses runtime default if unset. May also be set in PodSecurityContext. If set in both SecurityContext and PodSecurityContext, the value specified in SecurityContext takes precedence.",
+			Optional:    true,
+		},
 		"run_as_non_root": {
 			Type:        schema.TypeBool,
 			Description: "Indicates that the container must run as a non-root user. If true, the Kubelet will validate the image at runtime to ensure that it does not run as UID 0 (root) and fail to start the container if it does.",
@@ -41,10 +41,10 @@ func cronJobSpecFields() map[string]*schema.Schema {
 			},
 		},
 		"schedule": {
-			Type:     schema.TypeString,
-			Required: true,
-			//ValidateFunc: validate, TODO: validate cron syntax..
-			Description: "Cron format string, e.g. 0 * * * * or @hourly, as schedule time of its jobs to be created and executed.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateCronSchedule,
+			Description:  "Cron format string, e.g. 0 * * * * or @hourly, as schedule time of its jobs to be created and executed.",
 		},
 		"starting_deadline_seconds": {
 			Type:        schema.TypeInt,
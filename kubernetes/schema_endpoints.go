@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func endpointsSubsetSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:        schema.TypeSet,
+				Description: "IP addresses which offer the related ports that are marked as ready. These endpoints should be considered safe for load balancers and clients to utilize.",
+				Optional:    true,
+				Elem:        endpointsAddressSchema(),
+			},
+			"not_ready_address": {
+				Type:        schema.TypeSet,
+				Description: "IP addresses which offer the related ports but are not currently marked as ready because they have not yet finished starting, have recently failed a readiness check, or have recently failed a liveness check.",
+				Optional:    true,
+				Elem:        endpointsAddressSchema(),
+			},
+			"port": {
+				Type:        schema.TypeSet,
+				Description: "Port numbers available on the related IP addresses.",
+				Optional:    true,
+				Elem:        endpointsPortSchema(),
+			},
+		},
+	}
+}
+
+func endpointsAddressSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Type:         schema.TypeString,
+				Description:  "The IP of this endpoint. May not be loopback (127.0.0.0/8), link-local (169.254.0.0/16), or link-local multicast ((224.0.0.0/24).",
+				Required:     true,
+				ValidateFunc: validateIP,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The Hostname of this endpoint.",
+				Optional:    true,
+			},
+			"node_name": {
+				Type:        schema.TypeString,
+				Description: "Node hosting this endpoint. This can be used to determine endpoints local to a node.",
+				Optional:    true,
+			},
+			"target_ref": {
+				Type:        schema.TypeList,
+				Description: "Reference to object providing the endpoint.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the referent, e.g. `Pod`.",
+							Optional:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the referent.",
+							Required:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the referent.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func endpointsPortSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of this port within the endpoint. Must match the name of the corresponding port of the service if it defines more than one port.",
+				Optional:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "The port that will be exposed by this endpoint.",
+				Required:     true,
+				ValidateFunc: validatePortNum,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "The IP protocol for this port. Supports `TCP`, `UDP` and `SCTP`. Default is `TCP`.",
+				Optional:     true,
+				Default:      "TCP",
+				ValidateFunc: validateAttributeValueIsIn([]string{"TCP", "UDP", "SCTP"}),
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func jobSpecFields() map[string]*schema.Schema {
@@ -83,6 +84,13 @@ func jobSpecFields() map[string]*schema.Schema {
 				},
 			},
 		},
+		"ttl_seconds_after_finished": {
+			// A string so that 0, deleting the job as soon as it finishes, can be told apart from unset
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateNonNegativeIntegerString,
+			Description:  "Limits the lifetime of a Job that has finished execution (either Complete or Failed). If set, the Job is eligible to be automatically deleted this many seconds after it finishes, 0 makes it eligible to be deleted immediately. Requires the TTLAfterFinished feature gate.",
+		},
 		"template": {
 			Type:        schema.TypeList,
 			Description: "Describes the pod that will be created when executing a job. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/",
@@ -101,3 +109,13 @@ func jobSpecFields() map[string]*schema.Schema {
 
 	return s
 }
+
+func deletionPropagationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Whether and how garbage collection is performed for dependents on delete. Either `Orphan`, `Background` or `Foreground`. Defaults to `Background`.",
+		Optional:     true,
+		Default:      "Background",
+		ValidateFunc: validation.StringInSlice([]string{"Orphan", "Background", "Foreground"}, false),
+	}
+}
@@ -2,6 +2,9 @@ package kubernetes
 
 import "github.com/hashicorp/terraform/helper/schema"
 
+// persistentVolumeClaimSpecFields returns the schema of a claim. Storage
+// requests of standalone claims can be expanded in place, the claims of
+// stateful set templates are immutable.
 func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
 		"metadata": namespacedMetadataSchema("persistent volume claim", true),
@@ -9,7 +12,7 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 			Type:        schema.TypeList,
 			Description: "Spec defines the desired characteristics of a volume requested by a pod author. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#persistentvolumeclaims",
 			Required:    true,
-			ForceNew:    true,
+			ForceNew:    pvcTemplate,
 			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -21,25 +24,60 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 						Elem:        &schema.Schema{Type: schema.TypeString},
 						Set:         schema.HashString,
 					},
+					"data_source": {
+						Type:        schema.TypeList,
+						Description: "An existing object to populate the volume from, such as a volume snapshot. Requires the VolumeSnapshotDataSource feature gate to be enabled.",
+						Optional:    true,
+						ForceNew:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"api_group": {
+									Type:        schema.TypeString,
+									Description: "The group of the referenced object. Required unless the kind is in the core API group.",
+									Optional:    true,
+									ForceNew:    true,
+								},
+								"kind": {
+									Type:        schema.TypeString,
+									Description: "The kind of the referenced object, e.g. VolumeSnapshot.",
+									Required:    true,
+									ForceNew:    true,
+								},
+								"name": {
+									Type:        schema.TypeString,
+									Description: "The name of the referenced object.",
+									Required:    true,
+									ForceNew:    true,
+								},
+							},
+						},
+					},
 					"resources": {
 						Type:        schema.TypeList,
 						Description: "A list of the minimum resources the volume should have. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#resources",
 						Required:    true,
-						ForceNew:    true,
+						ForceNew:    pvcTemplate,
 						MaxItems:    1,
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"limits": {
-									Type:        schema.TypeMap,
-									Description: "Map describing the maximum amount of compute resources allowed. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
-									Optional:    true,
-									ForceNew:    true,
+									Type:             schema.TypeMap,
+									Description:      "Map describing the maximum amount of compute resources allowed. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
+									Optional:         true,
+									ForceNew:         true,
+									Elem:             &schema.Schema{Type: schema.TypeString},
+									ValidateFunc:     validateResourceList,
+									DiffSuppressFunc: suppressEquivalentResourceQuantity,
 								},
 								"requests": {
-									Type:        schema.TypeMap,
-									Description: "Map describing the minimum amount of compute resources required. If this is omitted for a container, it defaults to `limits` if that is explicitly specified, otherwise to an implementation-defined value. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
-									Optional:    true,
-									ForceNew:    true,
+									Type:             schema.TypeMap,
+									Description:      "Map describing the minimum amount of compute resources required. If this is omitted for a container, it defaults to `limits` if that is explicitly specified, otherwise to an implementation-defined value. The storage request of a claim can be increased in place if its storage class allows volume expansion. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
+									Optional:         true,
+									ForceNew:         pvcTemplate,
+									Elem:             &schema.Schema{Type: schema.TypeString},
+									ValidateFunc:     validateResourceList,
+									DiffSuppressFunc: suppressEquivalentResourceQuantity,
 								},
 							},
 						},
@@ -91,6 +129,14 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 							},
 						},
 					},
+					"volume_mode": {
+						Type:         schema.TypeString,
+						Description:  "Whether the claimed volume is intended to be used with a formatted filesystem or to remain in raw block state. One of Filesystem or Block. Defaults to Filesystem.",
+						Optional:     true,
+						Computed:     true,
+						ForceNew:     true,
+						ValidateFunc: validateAttributeValueIsIn([]string{"Filesystem", "Block"}),
+					},
 					"volume_name": {
 						Type:        schema.TypeString,
 						Description: "The binding reference to the PersistentVolume backing this claim.",
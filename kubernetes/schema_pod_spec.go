@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func podTemplateSpecFields(isUpdatable bool) map[string]*schema.Schema {
@@ -85,6 +86,27 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Default:     false,
 			Description: "Use the host's ipc namespace. Optional: Default to false.",
 		},
+		"host_aliases": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "List of hosts and IPs that will be injected into the pod's hosts file if specified. This is only valid for non-hostNetwork pods.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"hostnames": {
+						Type:        schema.TypeList,
+						Required:    true,
+						Description: "Hostnames for the IP address.",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"ip": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "IP address of the host file entry.",
+						ValidateFunc: validateIP,
+					},
+				},
+			},
+		},
 		"host_network": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -150,6 +172,20 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Optional:    true,
 			Description: "If specified, indicates the pod's priority. \"system-node-critical\" and \"system-cluster-critical\" are two special keywords which indicate the highest priorities with the former being the highest priority. Any other name must be defined by creating a PriorityClass object with that name. If not specified, the pod priority will be default or zero if there is no default.",
 		},
+		"readiness_gate": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "If specified, all readiness gates will be evaluated for pod readiness. A pod is ready when all its containers are ready AND all conditions specified in the readiness gates have status equal to \"True\". More info: https://github.com/kubernetes/community/blob/master/keps/sig-network/0007-pod-ready%2B%2B.md",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"condition_type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Refers to a condition in the pod's condition list with matching type.",
+					},
+				},
+			},
+		},
 		"restart_policy": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -173,6 +209,11 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 						Description: "Indicates that the container must run as a non-root user. If true, the Kubelet will validate the image at runtime to ensure that it does not run as UID 0 (root) and fail to start the container if it does.",
 						Optional:    true,
 					},
+					"run_as_group": {
+						Type:        schema.TypeInt,
+						Description: "The GID to run the entrypoint of the container process. Uses runtime default if unset. May also be set in SecurityContext. If set in both SecurityContext and PodSecurityContext, the value specified in SecurityContext takes precedence for that container.",
+						Optional:    true,
+					},
 					"run_as_user": {
 						Type:        schema.TypeInt,
 						Description: "The UID to run the entrypoint of the container process. Defaults to user specified in image metadata if unspecified",
@@ -195,6 +236,25 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 							Schema: seLinuxOptionsField(),
 						},
 					},
+					"sysctl": {
+						Type:        schema.TypeList,
+						Description: "Namespaced sysctls used for the pod. Pods with sysctls unsupported by the container runtime might fail to launch.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Description: "Name of the property to set.",
+									Required:    true,
+								},
+								"value": {
+									Type:        schema.TypeString,
+									Description: "Value of the property to set.",
+									Required:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -210,6 +270,12 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Default:     true,
 			Description: "In version 1.6+, you can also opt out of automounting API credentials for a particular pod",
 		},
+		"share_process_namespace": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Share a single process namespace between all of the containers in a pod. When this is set containers will be able to view and signal processes from other containers in the same pod, and the first process in each container will not be assigned PID 1. host_pid and share_process_namespace cannot both be set.",
+		},
 		"subdomain": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -378,65 +444,7 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 					Type:        schema.TypeList,
 					Description: `If unspecified, each key-value pair in the Data field of the referenced ConfigMap will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the ConfigMap, the volume setup will error. Paths must be relative and may not contain the '..' path or start with '..'.`,
 					Optional:    true,
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"field_ref": {
-								Type:        schema.TypeList,
-								Required:    true,
-								MaxItems:    1,
-								Description: "Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.",
-								Elem: &schema.Resource{
-									Schema: map[string]*schema.Schema{
-										"api_version": {
-											Type:        schema.TypeString,
-											Optional:    true,
-											Default:     "v1",
-											Description: `Version of the schema the FieldPath is written in terms of, defaults to "v1".`,
-										},
-										"field_path": {
-											Type:        schema.TypeString,
-											Optional:    true,
-											Description: "Path of the field to select in the specified API version",
-										},
-									},
-								},
-							},
-							"mode": {
-								Type:        schema.TypeInt,
-								Optional:    true,
-								Description: `Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.`,
-							},
-							"path": {
-								Type:         schema.TypeString,
-								Required:     true,
-								ValidateFunc: validateAttributeValueDoesNotContain(".."),
-								Description:  `Path is the relative path name of the file to be created. Must not be absolute or contain the '..' path. Must be utf-8 encoded. The first item of the relative path must not start with '..'`,
-							},
-							"resource_field_ref": {
-								Type:        schema.TypeList,
-								Optional:    true,
-								MaxItems:    1,
-								Description: "Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
-								Elem: &schema.Resource{
-									Schema: map[string]*schema.Schema{
-										"container_name": {
-											Type:     schema.TypeString,
-											Required: true,
-										},
-										"quantity": {
-											Type:     schema.TypeString,
-											Optional: true,
-										},
-										"resource": {
-											Type:        schema.TypeString,
-											Required:    true,
-											Description: "Resource to select",
-										},
-									},
-								},
-							},
-						},
-					},
+					Elem:        downwardAPIVolumeFileSchema(),
 				},
 			},
 		},
@@ -508,26 +516,7 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 					Type:        schema.TypeList,
 					Description: "If unspecified, each key-value pair in the Data field of the referenced Secret will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the Secret, the volume setup will error unless it is marked optional. Paths must be relative and may not contain the '..' path or start with '..'.",
 					Optional:    true,
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"key": {
-								Type:        schema.TypeString,
-								Optional:    true,
-								Description: "The key to project.",
-							},
-							"mode": {
-								Type:        schema.TypeInt,
-								Optional:    true,
-								Description: "Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
-							},
-							"path": {
-								Type:         schema.TypeString,
-								Optional:     true,
-								ValidateFunc: validateAttributeValueDoesNotContain(".."),
-								Description:  "The relative path of the file to map the key to. May not be an absolute path. May not contain the path element '..'. May not start with the string '..'.",
-							},
-						},
-					},
+					Elem:        keyToPathSchema(),
 				},
 				"optional": {
 					Type:        schema.TypeBool,
@@ -542,6 +531,129 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 			},
 		},
 	}
+	v["projected"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Projected represents a projection of secrets, config maps, downward API and service account tokens into the same directory. More info: https://kubernetes.io/docs/concepts/storage/volumes/#projected",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default_mode": {
+					Type:         schema.TypeInt,
+					Description:  "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+					Optional:     true,
+					Default:      0644,
+					ValidateFunc: validateModeBits,
+				},
+				"sources": {
+					Type:        schema.TypeList,
+					Description: "The sources projected into the volume, each with exactly one of secret, config_map, downward_api or service_account_token.",
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"config_map": {
+								Type:        schema.TypeList,
+								Description: "Config map to project into the volume.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "If unspecified, each key-value pair in the Data field of the referenced ConfigMap will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present.",
+											Optional:    true,
+											Elem:        keyToPathSchema(),
+										},
+										"name": {
+											Type:        schema.TypeString,
+											Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+											Required:    true,
+										},
+										"optional": {
+											Type:        schema.TypeBool,
+											Description: "Optional: Specify whether the ConfigMap or it's keys must be defined.",
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"downward_api": {
+								Type:        schema.TypeList,
+								Description: "Downward API information to project into the volume.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "The files to project, each with a field of the pod or a resource of a container.",
+											Optional:    true,
+											Elem:        downwardAPIVolumeFileSchema(),
+										},
+									},
+								},
+							},
+							"secret": {
+								Type:        schema.TypeList,
+								Description: "Secret to project into the volume.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "If unspecified, each key-value pair in the Data field of the referenced Secret will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present.",
+											Optional:    true,
+											Elem:        keyToPathSchema(),
+										},
+										"name": {
+											Type:        schema.TypeString,
+											Description: "Name of the secret in the pod's namespace. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+											Required:    true,
+										},
+										"optional": {
+											Type:        schema.TypeBool,
+											Description: "Optional: Specify whether the Secret or it's keys must be defined.",
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"service_account_token": {
+								Type:        schema.TypeList,
+								Description: "Token of the service account of the pod to project into the volume.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"audience": {
+											Type:        schema.TypeString,
+											Description: "Intended audience of the token. A recipient of the token must identify itself with an identifier specified in the audience of the token. Defaults to the identifier of the API server.",
+											Optional:    true,
+										},
+										"expiration_seconds": {
+											Type:         schema.TypeInt,
+											Description:  "Requested duration of validity of the token, the kubelet rotates the token before it expires. Must be at least 600 seconds. Defaults to 3600.",
+											Optional:     true,
+											Default:      3600,
+											ValidateFunc: validation.IntAtLeast(600),
+										},
+										"path": {
+											Type:         schema.TypeString,
+											Description:  "Path of the file to project the token into, relative to the mount point.",
+											Required:     true,
+											ValidateFunc: validateAttributeValueDoesNotContain(".."),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	v["name"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Description: "Volume's name. Must be a DNS_LABEL and unique within the pod. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
@@ -551,3 +663,90 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 		Schema: v,
 	}
 }
+
+// downwardAPIVolumeFileSchema describes the files of downward API volumes and projections
+func downwardAPIVolumeFileSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"field_ref": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "v1",
+							Description: `Version of the schema the FieldPath is written in terms of, defaults to "v1".`,
+						},
+						"field_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path of the field to select in the specified API version",
+						},
+					},
+				},
+			},
+			"mode": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.`,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAttributeValueDoesNotContain(".."),
+				Description:  `Path is the relative path name of the file to be created. Must not be absolute or contain the '..' path. Must be utf-8 encoded. The first item of the relative path must not start with '..'`,
+			},
+			"resource_field_ref": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"container_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"resource": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Resource to select",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// keyToPathSchema describes the keys of secrets and config maps projected into volumes
+func keyToPathSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key to project.",
+			},
+			"mode": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAttributeValueDoesNotContain(".."),
+				Description:  "The relative path of the file to map the key to. May not be an absolute path. May not contain the path element '..'. May not start with the string '..'.",
+			},
+		},
+	}
+}
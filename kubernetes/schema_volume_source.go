@@ -5,8 +5,103 @@ import (
 )
 
 func persistentVolumeSourceSchema() *schema.Resource {
+	v := commonVolumeSources()
+	v["csi"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Represents storage that is handled by an external CSI driver. More info: https://kubernetes.io/docs/concepts/storage/volumes/#csi",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"controller_publish_secret_ref": {
+					Type:        schema.TypeList,
+					Description: "A reference to the secret object containing sensitive information to pass to the CSI driver to complete the CSI ControllerPublishVolume and ControllerUnpublishVolume calls.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem:        secretReferenceSchema(),
+				},
+				"driver": {
+					Type:        schema.TypeString,
+					Description: "The name of the driver to use for this volume.",
+					Required:    true,
+				},
+				"fs_type": {
+					Type:        schema.TypeString,
+					Description: "Filesystem type to mount. Must be a filesystem type supported by the host operating system. Ex. \"ext4\", \"xfs\", \"ntfs\".",
+					Optional:    true,
+				},
+				"node_publish_secret_ref": {
+					Type:        schema.TypeList,
+					Description: "A reference to the secret object containing sensitive information to pass to the CSI driver to complete the CSI NodePublishVolume and NodeUnpublishVolume calls.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem:        secretReferenceSchema(),
+				},
+				"node_stage_secret_ref": {
+					Type:        schema.TypeList,
+					Description: "A reference to the secret object containing sensitive information to pass to the CSI driver to complete the CSI NodeStageVolume and NodeUnstageVolume calls.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem:        secretReferenceSchema(),
+				},
+				"read_only": {
+					Type:        schema.TypeBool,
+					Description: "Whether to set the read-only property in VolumeMounts to \"true\". If omitted, the default is \"false\".",
+					Optional:    true,
+				},
+				"volume_attributes": {
+					Type:        schema.TypeMap,
+					Description: "Attributes of the volume to publish.",
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"volume_handle": {
+					Type:        schema.TypeString,
+					Description: "A string value that uniquely identifies the volume.",
+					Required:    true,
+				},
+			},
+		},
+	}
+	v["local"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Represents a mounted local storage device such as a disk, partition or directory. Local volumes can only be used as a statically created persistent volume and require node_affinity to be set. More info: https://kubernetes.io/docs/concepts/storage/volumes/#local",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"fs_type": {
+					Type:        schema.TypeString,
+					Description: "Filesystem type to mount. It applies only when the path is a block device. Must be a filesystem type supported by the host operating system. Ex. \"ext4\", \"xfs\", \"ntfs\". The default is to auto-select a filesystem if unspecified.",
+					Optional:    true,
+				},
+				"path": {
+					Type:        schema.TypeString,
+					Description: "The full path to the volume on the node. It can be either a directory or block device (disk, partition, ...).",
+					Required:    true,
+				},
+			},
+		},
+	}
 	return &schema.Resource{
-		Schema: commonVolumeSources(),
+		Schema: v,
+	}
+}
+
+func secretReferenceSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the secret. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+				Required:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the secret.",
+				Optional:    true,
+			},
+		},
 	}
 }
 
@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func waitForSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Conditions evaluated against the live object after create or update. Terraform blocks until all of them are met or the resource's timeout is reached.",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"condition": {
+					Type:        schema.TypeString,
+					Description: "Type of the status condition to wait for, e.g. `Ready`, `Available` or `Complete`. Conflicts with `field`.",
+					Optional:    true,
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Description: "Expected status of the condition. Defaults to `True`.",
+					Optional:    true,
+					Default:     "True",
+				},
+				"field": {
+					Type:        schema.TypeString,
+					Description: "Dot-separated path of a field in the live object, e.g. `status.phase` or `status.conditions.0.type`. Conflicts with `condition`.",
+					Optional:    true,
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Description: "Expected value of `field`.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func workloadStatusSchema(kind string, fields map[string]*schema.Schema) *schema.Schema {
+	fields["conditions"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The latest available observations of the " + kind + "'s current state.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:        schema.TypeString,
+					Description: "Type of the condition.",
+					Computed:    true,
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Description: "Status of the condition, one of `True`, `False` or `Unknown`.",
+					Computed:    true,
+				},
+				"reason": {
+					Type:        schema.TypeString,
+					Description: "The reason for the condition's last transition.",
+					Computed:    true,
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Description: "A human readable message indicating details about the transition.",
+					Computed:    true,
+				},
+				"last_transition_time": {
+					Type:        schema.TypeString,
+					Description: "Last time the condition transitioned from one status to another (RFC3339).",
+					Computed:    true,
+				},
+			},
+		},
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Most recently observed status of the " + kind + ". Read-only.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func computedIntField(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: description,
+		Computed:    true,
+	}
+}
+
+func computedStringField(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: description,
+		Computed:    true,
+	}
+}
+
+func deploymentStatusSchema() *schema.Schema {
+	return workloadStatusSchema("deployment", map[string]*schema.Schema{
+		"observed_generation":  computedIntField("The generation observed by the deployment controller."),
+		"replicas":             computedIntField("Total number of non-terminated pods targeted by this deployment (their labels match the selector)."),
+		"updated_replicas":     computedIntField("Total number of non-terminated pods targeted by this deployment that have the desired template spec."),
+		"ready_replicas":       computedIntField("Total number of ready pods targeted by this deployment."),
+		"available_replicas":   computedIntField("Total number of available pods (ready for at least min_ready_seconds) targeted by this deployment."),
+		"unavailable_replicas": computedIntField("Total number of unavailable pods targeted by this deployment."),
+		"collision_count":      computedIntField("Count of hash collisions for the deployment, used as a collision avoidance mechanism when creating the name of the newest ReplicaSet."),
+	})
+}
+
+func statefulSetStatusSchema() *schema.Schema {
+	return workloadStatusSchema("stateful set", map[string]*schema.Schema{
+		"observed_generation": computedIntField("The most recent generation observed for this stateful set."),
+		"replicas":            computedIntField("The number of pods created by the stateful set controller."),
+		"ready_replicas":      computedIntField("The number of pods created by the stateful set controller that have a Ready condition."),
+		"current_replicas":    computedIntField("The number of pods created by the stateful set controller from the stateful set version indicated by current_revision."),
+		"updated_replicas":    computedIntField("The number of pods created by the stateful set controller from the stateful set version indicated by update_revision."),
+		"current_revision":    computedStringField("The version of the stateful set used to generate pods in the sequence [0, current_replicas)."),
+		"update_revision":     computedStringField("The version of the stateful set used to generate pods in the sequence [replicas - updated_replicas, replicas)."),
+		"collision_count":     computedIntField("Count of hash collisions for the stateful set, used as a collision avoidance mechanism when creating the name of the newest ControllerRevision."),
+	})
+}
+
+func daemonSetStatusSchema() *schema.Schema {
+	return workloadStatusSchema("daemon set", map[string]*schema.Schema{
+		"observed_generation":      computedIntField("The most recent generation observed by the daemon set controller."),
+		"current_number_scheduled": computedIntField("The number of nodes that are running at least 1 daemon pod and are supposed to run the daemon pod."),
+		"desired_number_scheduled": computedIntField("The total number of nodes that should be running the daemon pod."),
+		"number_misscheduled":      computedIntField("The number of nodes that are running the daemon pod, but are not supposed to run the daemon pod."),
+		"number_ready":             computedIntField("The number of nodes that should be running the daemon pod and have one or more of the daemon pod running and ready."),
+		"updated_number_scheduled": computedIntField("The total number of nodes that are running updated daemon pod."),
+		"number_available":         computedIntField("The number of nodes that should be running the daemon pod and have one or more of the daemon pod running and available."),
+		"number_unavailable":       computedIntField("The number of nodes that should be running the daemon pod and have none of the daemon pod running and available."),
+		"collision_count":          computedIntField("Count of hash collisions for the daemon set, used as a collision avoidance mechanism when creating the name of the newest ControllerRevision."),
+	})
+}
+
+func jobStatusSchema() *schema.Schema {
+	return workloadStatusSchema("job", map[string]*schema.Schema{
+		"start_time":      computedStringField("Time when the job was acknowledged by the job controller (RFC3339)."),
+		"completion_time": computedStringField("Time when the job was completed (RFC3339)."),
+		"active":          computedIntField("The number of actively running pods."),
+		"succeeded":       computedIntField("The number of pods which reached phase Succeeded."),
+		"failed":          computedIntField("The number of pods which reached phase Failed."),
+	})
+}
+
+func replicationControllerStatusSchema() *schema.Schema {
+	return workloadStatusSchema("replication controller", map[string]*schema.Schema{
+		"observed_generation":    computedIntField("The generation of the most recently observed replication controller."),
+		"replicas":               computedIntField("The most recently observed number of replicas."),
+		"fully_labeled_replicas": computedIntField("The number of pods that have labels matching the labels of the pod template of the replication controller."),
+		"ready_replicas":         computedIntField("The number of ready replicas for this replication controller."),
+		"available_replicas":     computedIntField("The number of available replicas (ready for at least min_ready_seconds) for this replication controller."),
+	})
+}
@@ -0,0 +1,205 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// createStrategicMergePatch generates the strategic merge patch turning oldObj
+// into newObj, both are API objects of the same type. Lists are merged or
+// replaced according to the patchStrategy and patchMergeKey tags of the API
+// types, the same way the API server applies the patch.
+func createStrategicMergePatch(oldObj, newObj interface{}) ([]byte, error) {
+	oldJSON, err := toJSONValue(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newJSON, err := toJSONValue(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	o, _ := oldJSON.(map[string]interface{})
+	n, _ := newJSON.(map[string]interface{})
+	patch := diffStrategicObject(reflect.TypeOf(newObj), o, n)
+	if patch == nil {
+		patch = map[string]interface{}{}
+	}
+	return json.Marshal(patch)
+}
+
+type patchField struct {
+	Type     reflect.Type
+	Strategy string
+	MergeKey string
+}
+
+// patchFields returns the fields of a struct type by their JSON name,
+// including the fields of inlined structs
+func patchFields(t reflect.Type) map[string]patchField {
+	fields := make(map[string]patchField)
+	t = derefType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" && f.Anonymous {
+			for k, v := range patchFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = patchField{
+			Type:     f.Type,
+			Strategy: f.Tag.Get("patchStrategy"),
+			MergeKey: f.Tag.Get("patchMergeKey"),
+		}
+	}
+	return fields
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// diffStrategicObject returns the patch of a JSON object or nil if unchanged,
+// removed members are set to null
+func diffStrategicObject(t reflect.Type, oldV, newV map[string]interface{}) map[string]interface{} {
+	t = derefType(t)
+	var fields map[string]patchField
+	if t != nil && t.Kind() == reflect.Struct {
+		fields = patchFields(t)
+	}
+
+	patch := make(map[string]interface{})
+	for k := range oldV {
+		if _, ok := newV[k]; !ok {
+			patch[k] = nil
+		}
+	}
+
+	for k, n := range newV {
+		o, ok := oldV[k]
+		if !ok {
+			patch[k] = n
+			continue
+		}
+		if reflect.DeepEqual(o, n) {
+			continue
+		}
+
+		var f patchField
+		switch {
+		case fields != nil:
+			f = fields[k]
+		case t != nil && t.Kind() == reflect.Map:
+			f = patchField{Type: t.Elem()}
+		}
+
+		switch nv := n.(type) {
+		case map[string]interface{}:
+			if ov, ok := o.(map[string]interface{}); ok {
+				if p := diffStrategicObject(f.Type, ov, nv); p != nil {
+					patch[k] = p
+				}
+				continue
+			}
+		case []interface{}:
+			if ov, ok := o.([]interface{}); ok && strings.Contains(f.Strategy, "merge") {
+				diffStrategicList(patch, k, f, ov, nv)
+				continue
+			}
+		}
+		patch[k] = n
+	}
+
+	if len(patch) == 0 {
+		return nil
+	}
+	return patch
+}
+
+// diffStrategicList adds the patch of a list merged by the API server,
+// together with the directives keeping the order of its elements
+func diffStrategicList(patch map[string]interface{}, key string, f patchField, oldV, newV []interface{}) {
+	if f.MergeKey == "" {
+		// List of primitives, merged as a set
+		newItems := make(map[interface{}]bool, len(newV))
+		for _, v := range newV {
+			newItems[v] = true
+		}
+		deleted := make([]interface{}, 0)
+		for _, v := range oldV {
+			if !newItems[v] {
+				deleted = append(deleted, v)
+			}
+		}
+		patch[key] = newV
+		patch["$setElementOrder/"+key] = newV
+		if len(deleted) > 0 {
+			patch["$deleteFromPrimitiveList/"+key] = deleted
+		}
+		return
+	}
+
+	elemType := derefType(f.Type)
+	if elemType != nil && elemType.Kind() == reflect.Slice {
+		elemType = elemType.Elem()
+	}
+
+	oldByKey := make(map[interface{}]map[string]interface{}, len(oldV))
+	for _, v := range oldV {
+		if m, ok := v.(map[string]interface{}); ok {
+			oldByKey[m[f.MergeKey]] = m
+		}
+	}
+
+	items := make([]interface{}, 0)
+	order := make([]interface{}, 0, len(newV))
+	newKeys := make(map[interface{}]bool, len(newV))
+	for _, v := range newV {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		keyValue := m[f.MergeKey]
+		newKeys[keyValue] = true
+		order = append(order, map[string]interface{}{f.MergeKey: keyValue})
+
+		o, ok := oldByKey[keyValue]
+		if !ok {
+			items = append(items, m)
+			continue
+		}
+		if p := diffStrategicObject(elemType, o, m); p != nil {
+			p[f.MergeKey] = keyValue
+			items = append(items, p)
+		}
+	}
+	for _, v := range oldV {
+		m, ok := v.(map[string]interface{})
+		if !ok || newKeys[m[f.MergeKey]] {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			f.MergeKey: m[f.MergeKey],
+			"$patch":   "delete",
+		})
+	}
+
+	patch[key] = items
+	patch["$setElementOrder/"+key] = order
+}
@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateStrategicMergePatch(t *testing.T) {
+	deployment := func(image string, labels map[string]string, env []api.EnvVar, containers ...string) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: labels},
+		}
+		for _, name := range containers {
+			d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, api.Container{
+				Name:  name,
+				Image: image,
+				Env:   env,
+			})
+		}
+		return d
+	}
+
+	testCases := []struct {
+		Name     string
+		Old      *appsv1.Deployment
+		New      *appsv1.Deployment
+		Expected string
+	}{
+		{
+			Name:     "unchanged",
+			Old:      deployment("nginx:1.7", nil, nil, "web"),
+			New:      deployment("nginx:1.7", nil, nil, "web"),
+			Expected: `{}`,
+		},
+		{
+			Name: "changed container",
+			Old:  deployment("nginx:1.7", nil, nil, "web", "sidecar"),
+			New:  deployment("nginx:1.8", nil, nil, "web", "sidecar"),
+			Expected: `{"spec":{"template":{"spec":{
+				"$setElementOrder/containers":[{"name":"web"},{"name":"sidecar"}],
+				"containers":[{"image":"nginx:1.8","name":"web"},{"image":"nginx:1.8","name":"sidecar"}]}}}}`,
+		},
+		{
+			Name: "removed container",
+			Old:  deployment("nginx:1.7", nil, nil, "web", "sidecar"),
+			New:  deployment("nginx:1.7", nil, nil, "web"),
+			Expected: `{"spec":{"template":{"spec":{
+				"$setElementOrder/containers":[{"name":"web"}],
+				"containers":[{"$patch":"delete","name":"sidecar"}]}}}}`,
+		},
+		{
+			Name:     "removed label",
+			Old:      deployment("nginx:1.7", map[string]string{"app": "web", "tier": "frontend"}, nil, "web"),
+			New:      deployment("nginx:1.7", map[string]string{"app": "web"}, nil, "web"),
+			Expected: `{"metadata":{"labels":{"tier":null}}}`,
+		},
+		{
+			Name: "nested merge list",
+			Old:  deployment("nginx:1.7", nil, []api.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}, "web"),
+			New:  deployment("nginx:1.7", nil, []api.EnvVar{{Name: "A", Value: "3"}}, "web"),
+			Expected: `{"spec":{"template":{"spec":{
+				"$setElementOrder/containers":[{"name":"web"}],
+				"containers":[{"$setElementOrder/env":[{"name":"A"}],"env":[{"name":"A","value":"3"},{"$patch":"delete","name":"B"}],"name":"web"}]}}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		data, err := createStrategicMergePatch(tc.Old, tc.New)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.Name, err)
+		}
+		var patch, expected interface{}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			t.Fatalf("%s: %s", tc.Name, err)
+		}
+		if err := json.Unmarshal([]byte(tc.Expected), &expected); err != nil {
+			t.Fatalf("%s: %s", tc.Name, err)
+		}
+		if !reflect.DeepEqual(patch, expected) {
+			t.Fatalf("%s: expected patch:\n%s\ngot:\n%s", tc.Name, tc.Expected, string(data))
+		}
+	}
+}
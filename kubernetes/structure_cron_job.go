@@ -1,8 +1,13 @@
 package kubernetes
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/robfig/cron"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	api "k8s.io/api/core/v1"
 )
 
 func flattenCronJobSpec(in batchv1beta1.CronJobSpec, d *schema.ResourceData) ([]interface{}, error) {
@@ -44,7 +49,7 @@ func flattenJobTemplate(in batchv1beta1.JobTemplateSpec, d *schema.ResourceData)
 	meta := flattenMetadata(in.ObjectMeta, d)
 	att["metadata"] = meta
 
-	jobSpec, err := flattenJobSpec(in.Spec, d)
+	jobSpec, err := flattenJobSpec(in.Spec, d, "spec.0.job_template.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +98,9 @@ func expandCronJobSpec(j []interface{}) (batchv1beta1.CronJobSpec, error) {
 
 func expandJobTemplate(in []interface{}) (batchv1beta1.JobTemplateSpec, error) {
 	obj := batchv1beta1.JobTemplateSpec{}
+	if len(in) == 0 || in[0] == nil {
+		return obj, nil
+	}
 
 	tpl := in[0].(map[string]interface{})
 
@@ -109,3 +117,39 @@ func expandJobTemplate(in []interface{}) (batchv1beta1.JobTemplateSpec, error) {
 
 	return obj, nil
 }
+
+// cronJobNextScheduleTimes returns the next n times the schedule fires after from,
+// formatted as RFC3339 in UTC (the time zone of the cron job controller)
+func cronJobNextScheduleTimes(schedule string, suspend bool, from time.Time, n int) ([]interface{}, error) {
+	times := make([]interface{}, 0, n)
+	if suspend {
+		return times, nil
+	}
+
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("Unparseable schedule %q: %s", schedule, err)
+	}
+
+	t := from.UTC()
+	for i := 0; i < n; i++ {
+		t = sched.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t.Format(time.RFC3339))
+	}
+	return times, nil
+}
+
+func flattenObjectReferences(in []api.ObjectReference) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"name":      v.Name,
+			"namespace": v.Namespace,
+			"uid":       string(v.UID),
+		}
+	}
+	return att
+}
@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCronJobNextScheduleTimes(t *testing.T) {
+	from := time.Date(2018, time.December, 31, 22, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Schedule string
+		Suspend  bool
+		Expected []interface{}
+	}{
+		{
+			"0 * * * *",
+			false,
+			[]interface{}{"2018-12-31T23:00:00Z", "2019-01-01T00:00:00Z", "2019-01-01T01:00:00Z"},
+		},
+		{
+			"@daily",
+			false,
+			[]interface{}{"2019-01-01T00:00:00Z", "2019-01-02T00:00:00Z", "2019-01-03T00:00:00Z"},
+		},
+		{
+			"15 4 * * MON",
+			false,
+			[]interface{}{"2019-01-07T04:15:00Z", "2019-01-14T04:15:00Z", "2019-01-21T04:15:00Z"},
+		},
+		{
+			"@hourly",
+			true,
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		times, err := cronJobNextScheduleTimes(tc.Schedule, tc.Suspend, from, 3)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.Schedule, err)
+		}
+		if !reflect.DeepEqual(times, tc.Expected) {
+			t.Fatalf("%s: unexpected schedule times.\nExpected: %#v\nGiven:    %#v", tc.Schedule, tc.Expected, times)
+		}
+	}
+
+	if _, err := cronJobNextScheduleTimes("* * *", false, from, 3); err == nil {
+		t.Fatal("Expected an invalid schedule to fail")
+	}
+}
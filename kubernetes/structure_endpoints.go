@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+)
+
+// Flatteners
+
+// Set elements are hashed from all of their fields, so the flatteners
+// below set zero values too to produce the same hash as the config
+
+func flattenEndpointsSubsets(in []api.EndpointSubset) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["address"] = flattenEndpointsAddresses(n.Addresses)
+		m["not_ready_address"] = flattenEndpointsAddresses(n.NotReadyAddresses)
+		m["port"] = flattenEndpointsPorts(n.Ports)
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointsSubsetSchema()), att)
+}
+
+func flattenEndpointsAddresses(in []api.EndpointAddress) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["ip"] = n.IP
+		m["hostname"] = n.Hostname
+		m["node_name"] = ""
+		if n.NodeName != nil {
+			m["node_name"] = *n.NodeName
+		}
+		m["target_ref"] = []interface{}{}
+		if n.TargetRef != nil {
+			m["target_ref"] = flattenEndpointsTargetRef(n.TargetRef)
+		}
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointsAddressSchema()), att)
+}
+
+func flattenEndpointsTargetRef(in *api.ObjectReference) []interface{} {
+	att := make(map[string]interface{})
+	att["kind"] = in.Kind
+	att["name"] = in.Name
+	att["namespace"] = in.Namespace
+	return []interface{}{att}
+}
+
+func flattenEndpointsPorts(in []api.EndpointPort) *schema.Set {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		m := make(map[string]interface{})
+		m["name"] = n.Name
+		m["port"] = int(n.Port)
+		m["protocol"] = string(n.Protocol)
+		att[i] = m
+	}
+	return schema.NewSet(schema.HashResource(endpointsPortSchema()), att)
+}
+
+// Expanders
+
+func expandEndpointsSubsets(s *schema.Set) []api.EndpointSubset {
+	l := s.List()
+	obj := make([]api.EndpointSubset, 0, len(l))
+	for _, n := range l {
+		in, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		subset := api.EndpointSubset{}
+		if v, ok := in["address"].(*schema.Set); ok && v.Len() > 0 {
+			subset.Addresses = expandEndpointsAddresses(v)
+		}
+		if v, ok := in["not_ready_address"].(*schema.Set); ok && v.Len() > 0 {
+			subset.NotReadyAddresses = expandEndpointsAddresses(v)
+		}
+		if v, ok := in["port"].(*schema.Set); ok && v.Len() > 0 {
+			subset.Ports = expandEndpointsPorts(v)
+		}
+		obj = append(obj, subset)
+	}
+	return obj
+}
+
+func expandEndpointsAddresses(s *schema.Set) []api.EndpointAddress {
+	l := s.List()
+	obj := make([]api.EndpointAddress, 0, len(l))
+	for _, n := range l {
+		in, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		addr := api.EndpointAddress{
+			IP: in["ip"].(string),
+		}
+		if v, ok := in["hostname"].(string); ok {
+			addr.Hostname = v
+		}
+		if v, ok := in["node_name"].(string); ok && v != "" {
+			addr.NodeName = ptrToString(v)
+		}
+		if v, ok := in["target_ref"].([]interface{}); ok && len(v) > 0 {
+			addr.TargetRef = expandEndpointsTargetRef(v)
+		}
+		obj = append(obj, addr)
+	}
+	return obj
+}
+
+func expandEndpointsTargetRef(l []interface{}) *api.ObjectReference {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &api.ObjectReference{
+		Name: in["name"].(string),
+	}
+	if v, ok := in["kind"].(string); ok {
+		obj.Kind = v
+	}
+	if v, ok := in["namespace"].(string); ok {
+		obj.Namespace = v
+	}
+	return obj
+}
+
+func expandEndpointsPorts(s *schema.Set) []api.EndpointPort {
+	l := s.List()
+	obj := make([]api.EndpointPort, 0, len(l))
+	for _, n := range l {
+		in, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		port := api.EndpointPort{
+			Port: int32(in["port"].(int)),
+		}
+		if v, ok := in["name"].(string); ok {
+			port.Name = v
+		}
+		if v, ok := in["protocol"].(string); ok {
+			port.Protocol = api.Protocol(v)
+		}
+		obj = append(obj, port)
+	}
+	return obj
+}
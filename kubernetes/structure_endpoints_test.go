@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestEndpointsSubsetsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesEndpoints().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "test"}},
+		"subset": []interface{}{
+			map[string]interface{}{
+				"address": []interface{}{
+					map[string]interface{}{"ip": "10.0.0.4"},
+					map[string]interface{}{
+						"ip":        "10.0.0.5",
+						"hostname":  "db-1",
+						"node_name": "node-1",
+						"target_ref": []interface{}{
+							map[string]interface{}{"kind": "Pod", "name": "db-1"},
+						},
+					},
+				},
+				"not_ready_address": []interface{}{
+					map[string]interface{}{"ip": "10.0.0.6"},
+				},
+				"port": []interface{}{
+					map[string]interface{}{"name": "sql", "port": 5432},
+				},
+			},
+		},
+	})
+	in := d.Get("subset").(*schema.Set)
+
+	subsets := expandEndpointsSubsets(in)
+	if len(subsets) != 1 {
+		t.Fatalf("Expected 1 subset, got %#v", subsets)
+	}
+	if len(subsets[0].Addresses) != 2 || len(subsets[0].NotReadyAddresses) != 1 || len(subsets[0].Ports) != 1 {
+		t.Fatalf("Unexpected subset: %#v", subsets[0])
+	}
+	if p := subsets[0].Ports[0]; p.Port != 5432 || p.Protocol != "TCP" || p.Name != "sql" {
+		t.Fatalf("Unexpected port: %#v", p)
+	}
+	for _, a := range subsets[0].Addresses {
+		if a.IP != "10.0.0.5" {
+			continue
+		}
+		if a.NodeName == nil || *a.NodeName != "node-1" || a.TargetRef == nil || a.TargetRef.Name != "db-1" {
+			t.Fatalf("Unexpected address: %#v", a)
+		}
+	}
+
+	out := flattenEndpointsSubsets(subsets)
+	if out.Difference(in).Len() > 0 || in.Difference(out).Len() > 0 {
+		t.Fatalf("Subsets didn't round trip, expected %#v, got %#v", in.List(), out.List())
+	}
+}
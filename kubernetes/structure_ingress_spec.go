@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/extensions/v1beta1"
 )
@@ -18,8 +20,8 @@ func flattenIngressRule(in []v1beta1.IngressRule) []interface{} {
 		pathAtts := make([]interface{}, len(n.HTTP.Paths), len(n.HTTP.Paths))
 		for i, p := range n.HTTP.Paths {
 			path := map[string]interface{}{
-				"path_regex": p.Path,
-				"backend":    flattenIngressBackend(&p.Backend),
+				"path":    p.Path,
+				"backend": flattenIngressBackend(&p.Backend),
 			}
 			pathAtts[i] = path
 		}
@@ -38,7 +40,7 @@ func flattenIngressBackend(in *v1beta1.IngressBackend) []interface{} {
 
 	m := make(map[string]interface{})
 	m["service_name"] = in.ServiceName
-	m["service_port"] = flattenIntOrString(in.ServicePort)
+	m["service_port"] = in.ServicePort.String()
 
 	att[0] = m
 
@@ -77,6 +79,27 @@ func flattenIngressTLS(in []v1beta1.IngressTLS) []interface{} {
 	return att
 }
 
+// keepIngressPathRegex moves flattened paths back to the deprecated
+// path_regex attribute wherever the configuration still uses it
+func keepIngressPathRegex(d *schema.ResourceData, spec []interface{}) {
+	att := spec[0].(map[string]interface{})
+	rules, ok := att["rule"].([]interface{})
+	if !ok {
+		return
+	}
+	for i, r := range rules {
+		http := r.(map[string]interface{})["http"].([]interface{})
+		for j, p := range http[0].(map[string]interface{})["path"].([]interface{}) {
+			path := p.(map[string]interface{})
+			key := fmt.Sprintf("spec.0.rule.%d.http.0.path.%d.path_regex", i, j)
+			if v, ok := d.Get(key).(string); ok && v != "" {
+				path["path_regex"] = path["path"]
+				delete(path, "path")
+			}
+		}
+	}
+}
+
 // Expanders
 
 func expandIngressRule(l []interface{}) []v1beta1.IngressRule {
@@ -99,9 +122,12 @@ func expandIngressRule(l []interface{}) []v1beta1.IngressRule {
 					for i, path := range pathList {
 						p := path.(map[string]interface{})
 						hip := v1beta1.HTTPIngressPath{
-							Path:    p["path_regex"].(string),
+							Path:    p["path"].(string),
 							Backend: *expandIngressBackend(p["backend"].([]interface{})),
 						}
+						if v, ok := p["path_regex"].(string); ok && v != "" {
+							hip.Path = v
+						}
 						paths[i] = hip
 					}
 				}
@@ -153,8 +179,8 @@ func expandIngressBackend(l []interface{}) *v1beta1.IngressBackend {
 		obj.ServiceName = v
 	}
 
-	if v, ok := in["service_port"].(int); ok {
-		obj.ServicePort = expandIntOrString(v)
+	if v, ok := in["service_port"].(string); ok && v != "" {
+		obj.ServicePort = expandPort(v)
 	}
 
 	return obj
@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestExpandIngressBackendServicePort(t *testing.T) {
+	cases := []struct {
+		Port     string
+		Expected intstr.IntOrString
+	}{
+		{"80", intstr.FromInt(80)},
+		{"http", intstr.FromString("http")},
+	}
+	for _, tc := range cases {
+		out := expandIngressBackend([]interface{}{
+			map[string]interface{}{
+				"service_name": "app",
+				"service_port": tc.Port,
+			},
+		})
+		if out.ServicePort != tc.Expected {
+			t.Fatalf("Expected %#v for %q, got %#v", tc.Expected, tc.Port, out.ServicePort)
+		}
+		flattened := flattenIngressBackend(out)[0].(map[string]interface{})
+		if flattened["service_port"] != tc.Port {
+			t.Fatalf("Expected %q to round trip, got %#v", tc.Port, flattened["service_port"])
+		}
+	}
+}
+
+func TestExpandIngressRulePathRegex(t *testing.T) {
+	rules := expandIngressRule([]interface{}{
+		map[string]interface{}{
+			"host": "server.domain.com",
+			"http": []interface{}{
+				map[string]interface{}{
+					"path": []interface{}{
+						map[string]interface{}{
+							"path":       "",
+							"path_regex": "/api",
+							"backend":    []interface{}{},
+						},
+						map[string]interface{}{
+							"path":       "/web",
+							"path_regex": "",
+							"backend":    []interface{}{},
+						},
+					},
+				},
+			},
+		},
+	})
+	paths := rules[0].HTTP.Paths
+	if paths[0].Path != "/api" {
+		t.Fatalf("Expected path_regex to be used as path, got %q", paths[0].Path)
+	}
+	if paths[1].Path != "/web" {
+		t.Fatalf("Expected path to be used, got %q", paths[1].Path)
+	}
+}
+
+func TestKeepIngressPathRegex(t *testing.T) {
+	rule := func(pathKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"rule": []interface{}{
+				map[string]interface{}{
+					"host": "server.domain.com",
+					"http": []interface{}{
+						map[string]interface{}{
+							"path": []interface{}{
+								map[string]interface{}{
+									pathKey:   "/api",
+									"backend": []interface{}{},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	d := schema.TestResourceDataRaw(t, resourceKubernetesIngress().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "test"}},
+		"spec":     []interface{}{rule("path_regex")},
+	})
+
+	spec := []interface{}{rule("path")}
+	keepIngressPathRegex(d, spec)
+
+	path := spec[0].(map[string]interface{})["rule"].([]interface{})[0].(map[string]interface{})["http"].([]interface{})[0].(map[string]interface{})["path"].([]interface{})[0].(map[string]interface{})
+	if path["path_regex"] != "/api" {
+		t.Fatalf("Expected path_regex to be kept, got %#v", path)
+	}
+	if _, ok := path["path"]; ok {
+		t.Fatalf("Expected path to be unset, got %#v", path)
+	}
+}
@@ -1,11 +1,14 @@
 package kubernetes
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData) ([]interface{}, error) {
+func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData, prefix ...string) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
 	if in.ActiveDeadlineSeconds != nil {
@@ -32,6 +35,10 @@ func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData) ([]interface{},
 		att["selector"] = flattenLabelSelector(in.Selector)
 	}
 
+	if in.TTLSecondsAfterFinished != nil {
+		att["ttl_seconds_after_finished"] = strconv.Itoa(int(*in.TTLSecondsAfterFinished))
+	}
+
 	// Remove server-generated labels
 	labels := in.Template.ObjectMeta.Labels
 
@@ -43,7 +50,11 @@ func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData) ([]interface{},
 		delete(labels, "job-name")
 	}
 
-	podSpec, err := flattenPodTemplateSpec(in.Template, d)
+	specPrefix := "spec.0."
+	if len(prefix) > 0 {
+		specPrefix = prefix[0] + specPrefix
+	}
+	podSpec, err := flattenPodTemplateSpec(in.Template, d, specPrefix+"template.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +96,14 @@ func expandJobSpec(j []interface{}) (batchv1.JobSpec, error) {
 		obj.Selector = expandLabelSelector(v)
 	}
 
+	if v, ok := in["ttl_seconds_after_finished"].(string); ok && v != "" {
+		ttl, err := strconv.Atoi(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.TTLSecondsAfterFinished = ptrToInt32(int32(ttl))
+	}
+
 	for _, v := range in["template"].([]interface{}) {
 		template := v.(map[string]interface{})
 		pts, err := expandPodTemplateSpec(template)
@@ -102,11 +121,68 @@ func patchJobSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOpera
 
 	if d.HasChange(prefix + "active_deadline_seconds") {
 		v := d.Get(prefix + "active_deadline_seconds").(int)
+		if v > 0 {
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "/activeDeadlineSeconds",
+				Value: v,
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: pathPrefix + "/activeDeadlineSeconds",
+			})
+		}
+	}
+
+	if d.HasChange(prefix + "backoff_limit") {
+		v := d.Get(prefix + "backoff_limit").(int)
+		if v > 0 {
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "/backoffLimit",
+				Value: v,
+			})
+		} else {
+			// Unset, the API server applies its default
+			ops = append(ops, &RemoveOperation{
+				Path: pathPrefix + "/backoffLimit",
+			})
+		}
+	}
+
+	if d.HasChange(prefix + "ttl_seconds_after_finished") {
+		v := d.Get(prefix + "ttl_seconds_after_finished").(string)
+		if v != "" {
+			ttl, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "/ttlSecondsAfterFinished",
+				Value: ttl,
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: pathPrefix + "/ttlSecondsAfterFinished",
+			})
+		}
+	}
+
+	if d.HasChange(prefix + "parallelism") {
+		v := d.Get(prefix + "parallelism").(int)
 		ops = append(ops, &ReplaceOperation{
-			Path:  pathPrefix + "/activeDeadlineSeconds",
+			Path:  pathPrefix + "/parallelism",
 			Value: v,
 		})
 	}
 
 	return ops, nil
 }
+
+func expandDeleteOptions(d *schema.ResourceData) *metav1.DeleteOptions {
+	policy := metav1.DeletePropagationBackground
+	if v, ok := d.GetOk("deletion_propagation"); ok {
+		policy = metav1.DeletionPropagation(v.(string))
+	}
+	return &metav1.DeleteOptions{
+		PropagationPolicy: &policy,
+	}
+}
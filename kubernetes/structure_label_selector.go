@@ -21,6 +21,19 @@ func flattenLabelSelector(in *metav1.LabelSelector) []interface{} {
 	return []interface{}{}
 }
 
+// flattenWorkloadSelector sets the selector of a deployment, daemon set or
+// stateful set spec. The label_selector block is only set when it's in use or
+// when the selector can't be written as the deprecated map of labels.
+func flattenWorkloadSelector(in *metav1.LabelSelector, att map[string]interface{}, d *schema.ResourceData) {
+	if in == nil {
+		return
+	}
+	att["selector"] = in.MatchLabels
+	if v, ok := d.Get("spec.0.label_selector").([]interface{}); (ok && len(v) > 0) || len(in.MatchExpressions) > 0 {
+		att["label_selector"] = flattenLabelSelector(in)
+	}
+}
+
 func flattenLabelSelectorRequirement(in []metav1.LabelSelectorRequirement) []interface{} {
 	att := make([]interface{}, len(in), len(in))
 	for i, n := range in {
@@ -50,6 +63,20 @@ func expandLabelSelector(l []interface{}) *metav1.LabelSelector {
 	return obj
 }
 
+// expandWorkloadSelector expands the selector of a deployment, daemon set or
+// stateful set spec from label_selector or, if it's not set, from the
+// deprecated map of labels
+func expandWorkloadSelector(in map[string]interface{}) *metav1.LabelSelector {
+	if v, ok := in["label_selector"].([]interface{}); ok && len(v) > 0 {
+		return expandLabelSelector(v)
+	}
+	obj := &metav1.LabelSelector{}
+	if v, ok := in["selector"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.MatchLabels = expandStringMap(v)
+	}
+	return obj
+}
+
 func expandLabelSelectorRequirement(l []interface{}) []metav1.LabelSelectorRequirement {
 	if len(l) == 0 || l[0] == nil {
 		return []metav1.LabelSelectorRequirement{}
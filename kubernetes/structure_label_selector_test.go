@@ -34,3 +34,49 @@ func TestFlattenLabelSelector(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandWorkloadSelector(t *testing.T) {
+	cases := []struct {
+		Input          map[string]interface{}
+		ExpectedOutput *metav1.LabelSelector
+	}{
+		{
+			map[string]interface{}{
+				"selector":       map[string]interface{}{"app": "web"},
+				"label_selector": []interface{}{},
+			},
+			&metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+		},
+		{
+			map[string]interface{}{
+				"selector": map[string]interface{}{"app": "web"},
+				"label_selector": []interface{}{
+					map[string]interface{}{
+						"match_labels":      map[string]interface{}{"app": "api"},
+						"match_expressions": []interface{}{},
+					},
+				},
+			},
+			&metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "api"},
+			},
+		},
+		{
+			map[string]interface{}{
+				"selector":       map[string]interface{}{},
+				"label_selector": []interface{}{},
+			},
+			&metav1.LabelSelector{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := expandWorkloadSelector(tc.Input)
+		if !reflect.DeepEqual(output, tc.ExpectedOutput) {
+			t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v",
+				tc.ExpectedOutput, output)
+		}
+	}
+}
@@ -20,6 +20,22 @@ func flattenPersistentVolumeClaimSpec(in v1.PersistentVolumeClaimSpec) []interfa
 	if in.StorageClassName != nil {
 		att["storage_class_name"] = *in.StorageClassName
 	}
+	if in.VolumeMode != nil {
+		att["volume_mode"] = string(*in.VolumeMode)
+	}
+	if in.DataSource != nil {
+		att["data_source"] = flattenTypedLocalObjectReference(in.DataSource)
+	}
+	return []interface{}{att}
+}
+
+func flattenTypedLocalObjectReference(in *v1.TypedLocalObjectReference) []interface{} {
+	att := make(map[string]interface{})
+	att["kind"] = in.Kind
+	att["name"] = in.Name
+	if in.APIGroup != nil {
+		att["api_group"] = *in.APIGroup
+	}
 	return []interface{}{att}
 }
 
@@ -58,9 +74,31 @@ func expandPersistentVolumeClaimSpec(l []interface{}) (v1.PersistentVolumeClaimS
 	if v, ok := in["storage_class_name"].(string); ok && v != "" {
 		obj.StorageClassName = ptrToString(v)
 	}
+	if v, ok := in["volume_mode"].(string); ok && v != "" {
+		volumeMode := v1.PersistentVolumeMode(v)
+		obj.VolumeMode = &volumeMode
+	}
+	if v, ok := in["data_source"].([]interface{}); ok && len(v) > 0 {
+		obj.DataSource = expandTypedLocalObjectReference(v)
+	}
 	return obj, nil
 }
 
+func expandTypedLocalObjectReference(l []interface{}) *v1.TypedLocalObjectReference {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.TypedLocalObjectReference{
+		Kind: in["kind"].(string),
+		Name: in["name"].(string),
+	}
+	if v, ok := in["api_group"].(string); ok && v != "" {
+		obj.APIGroup = ptrToString(v)
+	}
+	return obj
+}
+
 func expandResourceRequirements(l []interface{}) (v1.ResourceRequirements, error) {
 	if len(l) == 0 || l[0] == nil {
 		return v1.ResourceRequirements{}, nil
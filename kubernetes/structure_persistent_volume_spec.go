@@ -99,6 +99,15 @@ func flattenCephFSPersistentVolumeSource(in *v1.CephFSPersistentVolumeSource) []
 	return []interface{}{att}
 }
 
+func flattenClaimRef(in *v1.ObjectReference) []interface{} {
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	if in.Namespace != "" {
+		att["namespace"] = in.Namespace
+	}
+	return []interface{}{att}
+}
+
 func flattenCinderVolumeSource(in *v1.CinderVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	att["volume_id"] = in.VolumeID
@@ -123,6 +132,31 @@ func flattenCinderPersistentVolumeSource(in *v1.CinderPersistentVolumeSource) []
 	return []interface{}{att}
 }
 
+func flattenCSIPersistentVolumeSource(in *v1.CSIPersistentVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["driver"] = in.Driver
+	att["volume_handle"] = in.VolumeHandle
+	if in.ReadOnly != false {
+		att["read_only"] = in.ReadOnly
+	}
+	if in.FSType != "" {
+		att["fs_type"] = in.FSType
+	}
+	if len(in.VolumeAttributes) > 0 {
+		att["volume_attributes"] = in.VolumeAttributes
+	}
+	if in.ControllerPublishSecretRef != nil {
+		att["controller_publish_secret_ref"] = flattenSecretReference(in.ControllerPublishSecretRef)
+	}
+	if in.NodeStageSecretRef != nil {
+		att["node_stage_secret_ref"] = flattenSecretReference(in.NodeStageSecretRef)
+	}
+	if in.NodePublishSecretRef != nil {
+		att["node_publish_secret_ref"] = flattenSecretReference(in.NodePublishSecretRef)
+	}
+	return []interface{}{att}
+}
+
 func flattenFCVolumeSource(in *v1.FCVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	att["target_ww_ns"] = newStringSet(schema.HashString, in.TargetWWNs)
@@ -248,6 +282,15 @@ func flattenISCSIPersistentVolumeSource(in *v1.ISCSIPersistentVolumeSource) []in
 	return []interface{}{att}
 }
 
+func flattenLocalVolumeSource(in *v1.LocalVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["path"] = in.Path
+	if in.FSType != nil {
+		att["fs_type"] = *in.FSType
+	}
+	return []interface{}{att}
+}
+
 func flattenLocalObjectReference(in *v1.LocalObjectReference) []interface{} {
 	att := make(map[string]interface{})
 	if in.Name != "" {
@@ -261,6 +304,9 @@ func flattenSecretReference(in *v1.SecretReference) []interface{} {
 	if in.Name != "" {
 		att["name"] = in.Name
 	}
+	if in.Namespace != "" {
+		att["namespace"] = in.Namespace
+	}
 	return []interface{}{att}
 }
 
@@ -324,6 +370,12 @@ func flattenPersistentVolumeSource(in v1.PersistentVolumeSource) []interface{} {
 	if in.PhotonPersistentDisk != nil {
 		att["photon_persistent_disk"] = flattenPhotonPersistentDiskVolumeSource(in.PhotonPersistentDisk)
 	}
+	if in.CSI != nil {
+		att["csi"] = flattenCSIPersistentVolumeSource(in.CSI)
+	}
+	if in.Local != nil {
+		att["local"] = flattenLocalVolumeSource(in.Local)
+	}
 	return []interface{}{att}
 }
 
@@ -346,6 +398,23 @@ func flattenPersistentVolumeSpec(in v1.PersistentVolumeSpec) []interface{} {
 	if in.StorageClassName != "" {
 		att["storage_class_name"] = in.StorageClassName
 	}
+	if in.ClaimRef != nil {
+		att["claim_ref"] = flattenClaimRef(in.ClaimRef)
+	}
+	if in.NodeAffinity != nil {
+		att["node_affinity"] = flattenVolumeNodeAffinity(in.NodeAffinity)
+	}
+	if in.VolumeMode != nil {
+		att["volume_mode"] = string(*in.VolumeMode)
+	}
+	return []interface{}{att}
+}
+
+func flattenVolumeNodeAffinity(in *v1.VolumeNodeAffinity) []interface{} {
+	att := make(map[string]interface{})
+	if in.Required != nil {
+		att["required"] = flattenNodeSelector(in.Required)
+	}
 	return []interface{}{att}
 }
 
@@ -557,6 +626,20 @@ func expandCephFSPersistentVolumeSource(l []interface{}) *v1.CephFSPersistentVol
 	return obj
 }
 
+func expandClaimRef(l []interface{}) *v1.ObjectReference {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.ObjectReference{
+		Name: in["name"].(string),
+	}
+	if v, ok := in["namespace"].(string); ok {
+		obj.Namespace = v
+	}
+	return obj
+}
+
 func expandCinderVolumeSource(l []interface{}) *v1.CinderVolumeSource {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.CinderVolumeSource{}
@@ -593,6 +676,36 @@ func expandCinderPersistentVolumeSource(l []interface{}) *v1.CinderPersistentVol
 	return obj
 }
 
+func expandCSIPersistentVolumeSource(l []interface{}) *v1.CSIPersistentVolumeSource {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.CSIPersistentVolumeSource{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.CSIPersistentVolumeSource{
+		Driver:       in["driver"].(string),
+		VolumeHandle: in["volume_handle"].(string),
+	}
+	if v, ok := in["read_only"].(bool); ok {
+		obj.ReadOnly = v
+	}
+	if v, ok := in["fs_type"].(string); ok {
+		obj.FSType = v
+	}
+	if v, ok := in["volume_attributes"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.VolumeAttributes = expandStringMap(v)
+	}
+	if v, ok := in["controller_publish_secret_ref"].([]interface{}); ok && len(v) > 0 {
+		obj.ControllerPublishSecretRef = expandSecretReference(v)
+	}
+	if v, ok := in["node_stage_secret_ref"].([]interface{}); ok && len(v) > 0 {
+		obj.NodeStageSecretRef = expandSecretReference(v)
+	}
+	if v, ok := in["node_publish_secret_ref"].([]interface{}); ok && len(v) > 0 {
+		obj.NodePublishSecretRef = expandSecretReference(v)
+	}
+	return obj
+}
+
 func expandFCVolumeSource(l []interface{}) *v1.FCVolumeSource {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.FCVolumeSource{}
@@ -755,6 +868,20 @@ func expandISCSIPersistentVolumeSource(l []interface{}) *v1.ISCSIPersistentVolum
 	return obj
 }
 
+func expandLocalVolumeSource(l []interface{}) *v1.LocalVolumeSource {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.LocalVolumeSource{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.LocalVolumeSource{
+		Path: in["path"].(string),
+	}
+	if v, ok := in["fs_type"].(string); ok && v != "" {
+		obj.FSType = ptrToString(v)
+	}
+	return obj
+}
+
 func expandLocalObjectReference(l []interface{}) *v1.LocalObjectReference {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.LocalObjectReference{}
@@ -776,6 +903,9 @@ func expandSecretReference(l []interface{}) *v1.SecretReference {
 	if v, ok := in["name"].(string); ok {
 		obj.Name = v
 	}
+	if v, ok := in["namespace"].(string); ok {
+		obj.Namespace = v
+	}
 	return obj
 }
 
@@ -848,6 +978,12 @@ func expandPersistentVolumeSource(l []interface{}) v1.PersistentVolumeSource {
 	if v, ok := in["photon_persistent_disk"].([]interface{}); ok && len(v) > 0 {
 		obj.PhotonPersistentDisk = expandPhotonPersistentDiskVolumeSource(v)
 	}
+	if v, ok := in["csi"].([]interface{}); ok && len(v) > 0 {
+		obj.CSI = expandCSIPersistentVolumeSource(v)
+	}
+	if v, ok := in["local"].([]interface{}); ok && len(v) > 0 {
+		obj.Local = expandLocalVolumeSource(v)
+	}
 	return obj
 }
 
@@ -879,9 +1015,31 @@ func expandPersistentVolumeSpec(l []interface{}) (v1.PersistentVolumeSpec, error
 	if v, ok := in["storage_class_name"].(string); ok {
 		obj.StorageClassName = v
 	}
+	if v, ok := in["claim_ref"].([]interface{}); ok && len(v) > 0 {
+		obj.ClaimRef = expandClaimRef(v)
+	}
+	if v, ok := in["node_affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.NodeAffinity = expandVolumeNodeAffinity(v)
+	}
+	if v, ok := in["volume_mode"].(string); ok && v != "" {
+		volumeMode := v1.PersistentVolumeMode(v)
+		obj.VolumeMode = &volumeMode
+	}
 	return obj, nil
 }
 
+func expandVolumeNodeAffinity(l []interface{}) *v1.VolumeNodeAffinity {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.VolumeNodeAffinity{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.VolumeNodeAffinity{}
+	if v, ok := in["required"].([]interface{}); ok && len(v) > 0 {
+		obj.Required = expandNodeSelector(v)
+	}
+	return obj
+}
+
 func expandPhotonPersistentDiskVolumeSource(l []interface{}) *v1.PhotonPersistentDiskVolumeSource {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.PhotonPersistentDiskVolumeSource{}
@@ -1029,6 +1187,17 @@ func patchPersistentVolumeSpec(pathPrefix, prefix string, d *schema.ResourceData
 			Value: v1.PersistentVolumeReclaimPolicy(v),
 		})
 	}
+	if d.HasChange(prefix + "claim_ref") {
+		v := d.Get(prefix + "claim_ref").([]interface{})
+		if len(v) > 0 {
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "/claimRef",
+				Value: expandClaimRef(v),
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{Path: pathPrefix + "/claimRef"})
+		}
+	}
 	if d.HasChange(prefix + "storage_class_name") {
 		o, n := d.GetChange(prefix + "storage_class_name")
 		if v, ok := o.(string); ok && len(v) > 0 {
@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/api/core/v1"
+)
+
+func TestPersistentVolumeSourceRoundTrip(t *testing.T) {
+	cases := []v1.PersistentVolumeSource{
+		{
+			CSI: &v1.CSIPersistentVolumeSource{
+				Driver:       "csi.example.com",
+				VolumeHandle: "vol-0123456789",
+				FSType:       "ext4",
+				ReadOnly:     true,
+				NodePublishSecretRef: &v1.SecretReference{
+					Name:      "csi-secret",
+					Namespace: "kube-system",
+				},
+			},
+		},
+		{
+			Local: &v1.LocalVolumeSource{
+				Path:   "/mnt/disks/ssd1",
+				FSType: ptrToString("xfs"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		out := expandPersistentVolumeSource(flattenPersistentVolumeSource(tc))
+		if !reflect.DeepEqual(out, tc) {
+			t.Fatalf("Unexpected persistent volume source after round trip.\nExpected: %#v\nGiven:    %#v", tc, out)
+		}
+	}
+
+	csi := expandCSIPersistentVolumeSource([]interface{}{
+		map[string]interface{}{
+			"driver":            "csi.example.com",
+			"volume_handle":     "vol-0123456789",
+			"volume_attributes": map[string]interface{}{"storage.example.com/tier": "ssd"},
+		},
+	})
+	if csi.VolumeAttributes["storage.example.com/tier"] != "ssd" {
+		t.Fatalf("Unexpected volume attributes: %#v", csi.VolumeAttributes)
+	}
+}
+
+func TestExpandPersistentVolumeSpec(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"claim_ref": []interface{}{
+				map[string]interface{}{
+					"name":      "data",
+					"namespace": "default",
+				},
+			},
+			"node_affinity": []interface{}{
+				map[string]interface{}{
+					"required": []interface{}{
+						map[string]interface{}{
+							"node_selector_term": []interface{}{
+								map[string]interface{}{
+									"match_expressions": []interface{}{
+										map[string]interface{}{
+											"key":      "kubernetes.io/hostname",
+											"operator": "In",
+											"values":   newStringSet(schema.HashString, []string{"node-1"}),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"volume_mode": "Block",
+		},
+	}
+
+	spec, err := expandPersistentVolumeSpec(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.ClaimRef == nil || spec.ClaimRef.Name != "data" || spec.ClaimRef.Namespace != "default" {
+		t.Fatalf("Unexpected claim reference: %#v", spec.ClaimRef)
+	}
+	if spec.VolumeMode == nil || *spec.VolumeMode != v1.PersistentVolumeBlock {
+		t.Fatalf("Unexpected volume mode: %#v", spec.VolumeMode)
+	}
+	if spec.NodeAffinity == nil || spec.NodeAffinity.Required == nil {
+		t.Fatalf("Expected node affinity to be set, given: %#v", spec.NodeAffinity)
+	}
+	terms := spec.NodeAffinity.Required.NodeSelectorTerms
+	if len(terms) != 1 || terms[0].MatchExpressions[0].Key != "kubernetes.io/hostname" {
+		t.Fatalf("Unexpected node selector terms: %#v", terms)
+	}
+
+	flattened := flattenPersistentVolumeSpec(spec)[0].(map[string]interface{})
+	if flattened["volume_mode"] != "Block" {
+		t.Fatalf("Unexpected flattened volume mode: %#v", flattened["volume_mode"])
+	}
+	if _, ok := flattened["node_affinity"]; !ok {
+		t.Fatalf("Expected node affinity to be flattened, given: %#v", flattened)
+	}
+}
+
+func TestPersistentVolumeClaimDataSourceRoundTrip(t *testing.T) {
+	in := &v1.TypedLocalObjectReference{
+		APIGroup: ptrToString("snapshot.storage.k8s.io"),
+		Kind:     "VolumeSnapshot",
+		Name:     "data-snapshot",
+	}
+	out := expandTypedLocalObjectReference(flattenTypedLocalObjectReference(in))
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected data source after round trip.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	api "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	isDefaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaIsDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// Flatteners
+
+func flattenTopologySelectorTerms(in []corev1.TopologySelectorTerm) []interface{} {
+	att := make([]interface{}, len(in), len(in))
+	for i, n := range in {
+		exprs := make([]interface{}, len(n.MatchLabelExpressions), len(n.MatchLabelExpressions))
+		for j, e := range n.MatchLabelExpressions {
+			exprs[j] = map[string]interface{}{
+				"key":    e.Key,
+				"values": newStringSet(schema.HashString, e.Values),
+			}
+		}
+		att[i] = map[string]interface{}{
+			"match_label_expressions": exprs,
+		}
+	}
+	return att
+}
+
+// Expanders
+
+func expandTopologySelectorTerms(l []interface{}) []corev1.TopologySelectorTerm {
+	if len(l) == 0 {
+		return []corev1.TopologySelectorTerm{}
+	}
+	obj := make([]corev1.TopologySelectorTerm, len(l), len(l))
+	for i, n := range l {
+		in, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := in["match_label_expressions"].([]interface{}); ok {
+			exprs := make([]corev1.TopologySelectorLabelRequirement, 0, len(v))
+			for _, e := range v {
+				expr, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				exprs = append(exprs, corev1.TopologySelectorLabelRequirement{
+					Key:    expr["key"].(string),
+					Values: schemaSetToStringArray(expr["values"].(*schema.Set)),
+				})
+			}
+			obj[i].MatchLabelExpressions = exprs
+		}
+	}
+	return obj
+}
+
+// isDefaultStorageClass follows the API server's admission plugin
+// in honouring the beta annotation too
+func isDefaultStorageClass(sc *api.StorageClass) bool {
+	if sc.Annotations[isDefaultStorageClassAnnotation] == "true" {
+		return true
+	}
+	return sc.Annotations[betaIsDefaultStorageClassAnnotation] == "true"
+}
+
+// patchDefaultStorageClassAnnotation sets or clears the default class
+// annotations with a merge patch, leaving other annotations untouched
+func patchDefaultStorageClassAnnotation(conn *kubernetes.Clientset, name string, isDefault bool) error {
+	annotations := map[string]interface{}{
+		isDefaultStorageClassAnnotation: nil,
+	}
+	if isDefault {
+		annotations[isDefaultStorageClassAnnotation] = "true"
+	} else {
+		annotations[betaIsDefaultStorageClassAnnotation] = nil
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to marshal default class patch: %s", err)
+	}
+	log.Printf("[INFO] Patching default class annotation of storage class %q: %s", name, string(data))
+	_, err = conn.StorageV1().StorageClasses().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update default class annotation of storage class %q: %s", name, err)
+	}
+	return nil
+}
+
+// unsetOtherDefaultStorageClasses makes sure the given storage class
+// is the only one marked as default in the cluster
+func unsetOtherDefaultStorageClasses(conn *kubernetes.Clientset, name string) error {
+	list, err := conn.StorageV1().StorageClasses().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to list storage classes: %s", err)
+	}
+	for _, sc := range list.Items {
+		if sc.Name == name || !isDefaultStorageClass(&sc) {
+			continue
+		}
+		log.Printf("[INFO] Unsetting default class annotation of storage class %q", sc.Name)
+		err = patchDefaultStorageClassAnnotation(conn, sc.Name, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
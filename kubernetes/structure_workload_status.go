@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func flattenStatusCondition(condType, status, reason, message string, lastTransitionTime metav1.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 condType,
+		"status":               status,
+		"reason":               reason,
+		"message":              message,
+		"last_transition_time": flattenStatusTime(&lastTransitionTime),
+	}
+}
+
+func flattenStatusTime(in *metav1.Time) string {
+	if in == nil || in.IsZero() {
+		return ""
+	}
+	return in.UTC().Format(time.RFC3339)
+}
+
+func flattenCollisionCount(in *int32) int {
+	if in == nil {
+		return 0
+	}
+	return int(*in)
+}
+
+func flattenDeploymentStatus(in appsv1.DeploymentStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenStatusCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime)
+	}
+
+	att := map[string]interface{}{
+		"observed_generation":  int(in.ObservedGeneration),
+		"replicas":             int(in.Replicas),
+		"updated_replicas":     int(in.UpdatedReplicas),
+		"ready_replicas":       int(in.ReadyReplicas),
+		"available_replicas":   int(in.AvailableReplicas),
+		"unavailable_replicas": int(in.UnavailableReplicas),
+		"collision_count":      flattenCollisionCount(in.CollisionCount),
+		"conditions":           conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenStatefulSetStatus(in appsv1.StatefulSetStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenStatusCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime)
+	}
+
+	att := map[string]interface{}{
+		"observed_generation": int(in.ObservedGeneration),
+		"replicas":            int(in.Replicas),
+		"ready_replicas":      int(in.ReadyReplicas),
+		"current_replicas":    int(in.CurrentReplicas),
+		"updated_replicas":    int(in.UpdatedReplicas),
+		"current_revision":    in.CurrentRevision,
+		"update_revision":     in.UpdateRevision,
+		"collision_count":     flattenCollisionCount(in.CollisionCount),
+		"conditions":          conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenDaemonSetStatus(in appsv1.DaemonSetStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenStatusCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime)
+	}
+
+	att := map[string]interface{}{
+		"observed_generation":      int(in.ObservedGeneration),
+		"current_number_scheduled": int(in.CurrentNumberScheduled),
+		"desired_number_scheduled": int(in.DesiredNumberScheduled),
+		"number_misscheduled":      int(in.NumberMisscheduled),
+		"number_ready":             int(in.NumberReady),
+		"updated_number_scheduled": int(in.UpdatedNumberScheduled),
+		"number_available":         int(in.NumberAvailable),
+		"number_unavailable":       int(in.NumberUnavailable),
+		"collision_count":          flattenCollisionCount(in.CollisionCount),
+		"conditions":               conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenJobStatus(in batchv1.JobStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenStatusCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime)
+	}
+
+	att := map[string]interface{}{
+		"start_time":      flattenStatusTime(in.StartTime),
+		"completion_time": flattenStatusTime(in.CompletionTime),
+		"active":          int(in.Active),
+		"succeeded":       int(in.Succeeded),
+		"failed":          int(in.Failed),
+		"conditions":      conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenReplicationControllerStatus(in api.ReplicationControllerStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenStatusCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime)
+	}
+
+	att := map[string]interface{}{
+		"observed_generation":    int(in.ObservedGeneration),
+		"replicas":               int(in.Replicas),
+		"fully_labeled_replicas": int(in.FullyLabeledReplicas),
+		"ready_replicas":         int(in.ReadyReplicas),
+		"available_replicas":     int(in.AvailableReplicas),
+		"conditions":             conditions,
+	}
+	return []interface{}{att}
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlattenDeploymentStatus(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2018, time.November, 2, 10, 4, 5, 0, time.UTC))
+	in := appsv1.DeploymentStatus{
+		ObservedGeneration:  3,
+		Replicas:            2,
+		UpdatedReplicas:     2,
+		ReadyReplicas:       1,
+		AvailableReplicas:   1,
+		UnavailableReplicas: 1,
+		Conditions: []appsv1.DeploymentCondition{
+			{
+				Type:               appsv1.DeploymentAvailable,
+				Status:             api.ConditionFalse,
+				Reason:             "MinimumReplicasUnavailable",
+				Message:            "Deployment does not have minimum availability.",
+				LastTransitionTime: transition,
+			},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"observed_generation":  3,
+			"replicas":             2,
+			"updated_replicas":     2,
+			"ready_replicas":       1,
+			"available_replicas":   1,
+			"unavailable_replicas": 1,
+			"collision_count":      0,
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":                 "Available",
+					"status":               "False",
+					"reason":               "MinimumReplicasUnavailable",
+					"message":              "Deployment does not have minimum availability.",
+					"last_transition_time": "2018-11-02T10:04:05Z",
+				},
+			},
+		},
+	}
+
+	out := flattenDeploymentStatus(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected status.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
+
+func TestFlattenJobStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2018, time.November, 2, 10, 0, 0, 0, time.UTC))
+	in := batchv1.JobStatus{
+		StartTime: &start,
+		Active:    1,
+		Failed:    2,
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"start_time":      "2018-11-02T10:00:00Z",
+			"completion_time": "",
+			"active":          1,
+			"succeeded":       0,
+			"failed":          2,
+			"conditions":      []interface{}{},
+		},
+	}
+
+	out := flattenJobStatus(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected status.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -69,6 +70,24 @@ func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOp
 	return ops
 }
 
+// withResourceVersionTest guards a patch against changes made since the last
+// refresh when optimistic concurrency is enabled on the provider, the API
+// server rejects the whole patch if the resource version doesn't match
+func withResourceVersionTest(d *schema.ResourceData, meta interface{}, ops PatchOperations) PatchOperations {
+	if !meta.(*kubernetesProvider).optimisticConcurrency {
+		return ops
+	}
+	resourceVersion := d.Get("metadata.0.resource_version").(string)
+	if resourceVersion == "" {
+		return ops
+	}
+	test := &TestOperation{
+		Path:  "/metadata/resourceVersion",
+		Value: resourceVersion,
+	}
+	return append(PatchOperations{test}, ops...)
+}
+
 func expandStringMap(m map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 	for k, v := range m {
@@ -206,6 +225,16 @@ func ptrToInt64(i int64) *int64 {
 	return &i
 }
 
+// normalizeBoolString stores booleans kept in string attributes as true or
+// false, whichever spelling the configuration used
+func normalizeBoolString(v interface{}) string {
+	b, err := strconv.ParseBool(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return strconv.FormatBool(b)
+}
+
 func sliceOfString(slice []interface{}) []string {
 	result := make([]string, len(slice), len(slice))
 	for i, s := range slice {
@@ -231,6 +260,27 @@ func flattenResourceList(l api.ResourceList) map[string]string {
 	return m
 }
 
+// flattenConfiguredResourceList flattens a resource list, keeping only the
+// resources already set at key when there are any. Resources added by the
+// server (e.g. defaults of a LimitRange) would otherwise show up as removed
+// in every plan.
+func flattenConfiguredResourceList(l api.ResourceList, d *schema.ResourceData, key string) map[string]string {
+	m := flattenResourceList(l)
+	if d == nil {
+		return m
+	}
+	configured, ok := d.Get(key).(map[string]interface{})
+	if !ok || len(configured) == 0 {
+		return m
+	}
+	for k := range m {
+		if _, ok := configured[k]; !ok {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
 func expandMapToResourceList(m map[string]interface{}) (api.ResourceList, error) {
 	out := make(map[api.ResourceName]resource.Quantity)
 	for stringKey, origValue := range m {
@@ -1,8 +1,13 @@
 package kubernetes
 
 import (
+	"fmt"
+	"log"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -18,13 +23,22 @@ func flattenCapability(in []v1.Capability) []string {
 func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	att := make(map[string]interface{})
 
+	if in.AllowPrivilegeEscalation != nil {
+		att["allow_privilege_escalation"] = strconv.FormatBool(*in.AllowPrivilegeEscalation)
+	}
 	if in.Privileged != nil {
 		att["privileged"] = *in.Privileged
 	}
+	if in.ProcMount != nil {
+		att["proc_mount"] = string(*in.ProcMount)
+	}
 	if in.ReadOnlyRootFilesystem != nil {
 		att["read_only_root_filesystem"] = *in.ReadOnlyRootFilesystem
 	}
 
+	if in.RunAsGroup != nil {
+		att["run_as_group"] = *in.RunAsGroup
+	}
 	if in.RunAsNonRoot != nil {
 		att["run_as_non_root"] = *in.RunAsNonRoot
 	}
@@ -273,6 +287,9 @@ func flattenContainerVolumeMounts(in []v1.VolumeMount) ([]interface{}, error) {
 			m["mount_path"] = v.MountPath
 
 		}
+		if v.MountPropagation != nil {
+			m["mount_propagation"] = string(*v.MountPropagation)
+		}
 		if v.Name != "" {
 			m["name"] = v.Name
 
@@ -285,6 +302,17 @@ func flattenContainerVolumeMounts(in []v1.VolumeMount) ([]interface{}, error) {
 	return att, nil
 }
 
+func flattenContainerVolumeDevices(in []v1.VolumeDevice) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"device_path": v.DevicePath,
+			"name":        v.Name,
+		}
+	}
+	return att
+}
+
 func flattenContainerEnvs(in []v1.EnvVar) []interface{} {
 	att := make([]interface{}, len(in))
 	for i, v := range in {
@@ -344,18 +372,18 @@ func flattenContainerPorts(in []v1.ContainerPort) []interface{} {
 	return att
 }
 
-func flattenContainerResourceRequirements(in v1.ResourceRequirements) ([]interface{}, error) {
+func flattenContainerResourceRequirements(in v1.ResourceRequirements, d *schema.ResourceData, prefix string) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	if len(in.Limits) > 0 {
-		att["limits"] = []interface{}{flattenResourceList(in.Limits)}
+		att["limits"] = flattenConfiguredResourceList(in.Limits, d, prefix+"limits")
 	}
 	if len(in.Requests) > 0 {
-		att["requests"] = []interface{}{flattenResourceList(in.Requests)}
+		att["requests"] = flattenConfiguredResourceList(in.Requests, d, prefix+"requests")
 	}
 	return []interface{}{att}, nil
 }
 
-func flattenContainers(in []v1.Container) ([]interface{}, error) {
+func flattenContainers(in []v1.Container, d *schema.ResourceData, prefix string) ([]interface{}, error) {
 	att := make([]interface{}, len(in))
 	for i, v := range in {
 		c := make(map[string]interface{})
@@ -374,7 +402,7 @@ func flattenContainers(in []v1.Container) ([]interface{}, error) {
 		c["stdin_once"] = v.StdinOnce
 		c["tty"] = v.TTY
 		c["working_dir"] = v.WorkingDir
-		res, err := flattenContainerResourceRequirements(v.Resources)
+		res, err := flattenContainerResourceRequirements(v.Resources, d, fmt.Sprintf("%s.%d.resources.0.", prefix, i))
 		if err != nil {
 			return nil, err
 		}
@@ -403,6 +431,9 @@ func flattenContainers(in []v1.Container) ([]interface{}, error) {
 			c["env_from"] = flattenContainerEnvFroms(v.EnvFrom)
 		}
 
+		if len(v.VolumeDevices) > 0 {
+			c["volume_device"] = flattenContainerVolumeDevices(v.VolumeDevices)
+		}
 		if len(v.VolumeMounts) > 0 {
 			volumeMounts, err := flattenContainerVolumeMounts(v.VolumeMounts)
 			if err != nil {
@@ -499,6 +530,10 @@ func expandContainers(ctrs []interface{}) ([]v1.Container, error) {
 			cs[i].SecurityContext = expandContainerSecurityContext(v)
 		}
 
+		if v, ok := ctr["volume_device"].([]interface{}); ok && len(v) > 0 {
+			cs[i].VolumeDevices = expandContainerVolumeDevices(v)
+		}
+
 		if v, ok := ctr["volume_mount"].([]interface{}); ok && len(v) > 0 {
 			var err error
 			cs[i].VolumeMounts, err = expandContainerVolumeMounts(v)
@@ -544,12 +579,23 @@ func expandContainerSecurityContext(l []interface{}) *v1.SecurityContext {
 	}
 	in := l[0].(map[string]interface{})
 	obj := v1.SecurityContext{}
+	if v, ok := in["allow_privilege_escalation"].(string); ok && v != "" {
+		allow, _ := strconv.ParseBool(v)
+		obj.AllowPrivilegeEscalation = ptrToBool(allow)
+	}
 	if v, ok := in["privileged"]; ok {
 		obj.Privileged = ptrToBool(v.(bool))
 	}
+	if v, ok := in["proc_mount"].(string); ok && v != "" {
+		procMount := v1.ProcMountType(v)
+		obj.ProcMount = &procMount
+	}
 	if v, ok := in["read_only_root_filesystem"]; ok {
 		obj.ReadOnlyRootFilesystem = ptrToBool(v.(bool))
 	}
+	if v, ok := in["run_as_group"].(int); ok && v > 0 {
+		obj.RunAsGroup = ptrToInt64(int64(v))
+	}
 	if v, ok := in["run_as_non_root"]; ok {
 		obj.RunAsNonRoot = ptrToBool(v.(bool))
 	}
@@ -713,10 +759,26 @@ func expandContainerVolumeMounts(in []interface{}) ([]v1.VolumeMount, error) {
 		if subPath, ok := p["sub_path"]; ok {
 			vmp[i].SubPath = subPath.(string)
 		}
+		if propagation, ok := p["mount_propagation"].(string); ok && propagation != "" {
+			mode := v1.MountPropagationMode(propagation)
+			vmp[i].MountPropagation = &mode
+		}
 	}
 	return vmp, nil
 }
 
+func expandContainerVolumeDevices(in []interface{}) []v1.VolumeDevice {
+	devices := make([]v1.VolumeDevice, len(in))
+	for i, c := range in {
+		p := c.(map[string]interface{})
+		devices[i] = v1.VolumeDevice{
+			DevicePath: p["device_path"].(string),
+			Name:       p["name"].(string),
+		}
+	}
+	return devices
+}
+
 func expandContainerEnv(in []interface{}) ([]v1.EnvVar, error) {
 	if len(in) == 0 {
 		return []v1.EnvVar{}, nil
@@ -934,30 +996,16 @@ func expandContainerResourceRequirements(l []interface{}) (v1.ResourceRequiremen
 	in := l[0].(map[string]interface{})
 	obj := v1.ResourceRequirements{}
 
-	fn := func(in []interface{}) (v1.ResourceList, error) {
-		for _, c := range in {
-			p := c.(map[string]interface{})
-			if p["cpu"] == "" {
-				delete(p, "cpu")
-			}
-			if p["memory"] == "" {
-				delete(p, "memory")
-			}
-			return expandMapToResourceList(p)
-		}
-		return nil, nil
-	}
-
 	var err error
-	if v, ok := in["limits"].([]interface{}); ok && len(v) > 0 {
-		obj.Limits, err = fn(v)
+	if v, ok := in["limits"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Limits, err = expandMapToResourceList(v)
 		if err != nil {
 			return obj, err
 		}
 	}
 
-	if v, ok := in["requests"].([]interface{}); ok && len(v) > 0 {
-		obj.Requests, err = fn(v)
+	if v, ok := in["requests"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Requests, err = expandMapToResourceList(v)
 		if err != nil {
 			return obj, err
 		}
@@ -965,3 +1013,45 @@ func expandContainerResourceRequirements(l []interface{}) (v1.ResourceRequiremen
 
 	return obj, nil
 }
+
+// State migrations
+
+// migrateStateResourceRequirements moves container limits and requests stored
+// as single blocks of cpu and memory (e.g. resources.0.limits.0.cpu) to maps
+// of resource names (resources.0.limits.cpu)
+func migrateStateResourceRequirements(is *terraform.InstanceState) {
+	moved := make(map[string]string)
+	counts := make(map[string]int)
+
+	for k, v := range is.Attributes {
+		for _, field := range []string{".resources.0.limits.", ".resources.0.requests."} {
+			i := strings.Index(k, field)
+			if i < 0 {
+				continue
+			}
+			prefix := k[:i+len(field)]
+			rest := strings.TrimPrefix(k, prefix)
+			if rest != "#" && !strings.HasPrefix(rest, "0.") {
+				break
+			}
+
+			delete(is.Attributes, k)
+			if rest == "#" || v == "" {
+				// The count of the block and empty computed values are dropped
+				break
+			}
+			newK := prefix + strings.TrimPrefix(rest, "0.")
+			moved[newK] = v
+			counts[prefix+"%"]++
+			log.Printf("[DEBUG] moved attribute %s -> %s ", k, newK)
+			break
+		}
+	}
+
+	for k, v := range moved {
+		is.Attributes[k] = v
+	}
+	for k, v := range counts {
+		is.Attributes[k] = strconv.Itoa(v)
+	}
+}
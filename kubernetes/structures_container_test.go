@@ -0,0 +1,235 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestExpandContainerResourceRequirements(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"limits": map[string]interface{}{
+				"cpu":               "500m",
+				"memory":            "512Mi",
+				"nvidia.com/gpu":    "1",
+				"hugepages-2Mi":     "100Mi",
+				"ephemeral-storage": "2Gi",
+			},
+			"requests": map[string]interface{}{
+				"cpu": "250m",
+			},
+		},
+	}
+
+	out, err := expandContainerResourceRequirements(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:                    resource.MustParse("500m"),
+			v1.ResourceMemory:                 resource.MustParse("512Mi"),
+			v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+			v1.ResourceName("hugepages-2Mi"):  resource.MustParse("100Mi"),
+			v1.ResourceEphemeralStorage:       resource.MustParse("2Gi"),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("250m"),
+		},
+	}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Unexpected resource requirements.\nExpected: %#v\nGiven:    %#v", expected, out)
+	}
+
+	flattened, err := flattenContainerResourceRequirements(out, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits := flattened[0].(map[string]interface{})["limits"].(map[string]string)
+	if limits["nvidia.com/gpu"] != "1" || limits["hugepages-2Mi"] != "100Mi" || limits["ephemeral-storage"] != "2Gi" {
+		t.Fatalf("Expected extended resources to be kept, given: %#v", limits)
+	}
+
+	_, err = expandContainerResourceRequirements([]interface{}{
+		map[string]interface{}{
+			"limits": map[string]interface{}{"nvidia.com/gpu": "one"},
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected invalid quantities to be rejected")
+	}
+}
+
+func TestFlattenContainerResourceRequirementsPartiallyConfigured(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesPod().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "foo"}},
+		"spec": []interface{}{
+			map[string]interface{}{
+				"container": []interface{}{
+					map[string]interface{}{
+						"name":  "app",
+						"image": "nginx",
+						"resources": []interface{}{
+							map[string]interface{}{
+								"limits": map[string]interface{}{"cpu": "500m"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	// memory is injected by a LimitRange
+	in := []v1.Container{
+		{
+			Name:  "app",
+			Image: "nginx",
+			Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("500m"),
+					v1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("250m"),
+					v1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+	}
+
+	flattened, err := flattenContainers(in, d, "spec.0.container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := flattened[0].(map[string]interface{})["resources"].([]interface{})[0].(map[string]interface{})
+
+	expectedLimits := map[string]string{"cpu": "500m"}
+	if !reflect.DeepEqual(res["limits"], expectedLimits) {
+		t.Fatalf("Expected only the configured limits.\nExpected: %#v\nGiven:    %#v", expectedLimits, res["limits"])
+	}
+	expectedRequests := map[string]string{"cpu": "250m", "memory": "256Mi"}
+	if !reflect.DeepEqual(res["requests"], expectedRequests) {
+		t.Fatalf("Expected all requests when none are configured.\nExpected: %#v\nGiven:    %#v", expectedRequests, res["requests"])
+	}
+}
+
+func TestContainerSecurityContextRoundTrip(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"allow_privilege_escalation": "0",
+			"privileged":                 false,
+			"proc_mount":                 "Unmasked",
+			"read_only_root_filesystem":  true,
+			"run_as_group":               3000,
+			"run_as_non_root":            true,
+			"run_as_user":                1000,
+		},
+	}
+
+	out := expandContainerSecurityContext(in)
+	if out.AllowPrivilegeEscalation == nil || *out.AllowPrivilegeEscalation {
+		t.Fatalf("Expected privilege escalation to be explicitly disallowed, given: %#v", out.AllowPrivilegeEscalation)
+	}
+	if out.ProcMount == nil || *out.ProcMount != v1.UnmaskedProcMount {
+		t.Fatalf("Unexpected proc mount: %#v", out.ProcMount)
+	}
+	if out.RunAsGroup == nil || *out.RunAsGroup != 3000 {
+		t.Fatalf("Unexpected run_as_group: %#v", out.RunAsGroup)
+	}
+
+	flattened := flattenContainerSecurityContext(out)[0].(map[string]interface{})
+	expected := map[string]interface{}{
+		"allow_privilege_escalation": "false",
+		"privileged":                 false,
+		"proc_mount":                 "Unmasked",
+		"read_only_root_filesystem":  true,
+		"run_as_group":               int64(3000),
+		"run_as_non_root":            true,
+		"run_as_user":                int64(1000),
+	}
+	if !reflect.DeepEqual(flattened, expected) {
+		t.Fatalf("Unexpected flattened security context.\nExpected: %#v\nGiven:    %#v", expected, flattened)
+	}
+
+	// Unset optional fields are left to the API server defaults
+	out = expandContainerSecurityContext([]interface{}{
+		map[string]interface{}{
+			"allow_privilege_escalation": "",
+			"proc_mount":                 "",
+			"run_as_group":               0,
+		},
+	})
+	if out.AllowPrivilegeEscalation != nil || out.ProcMount != nil || out.RunAsGroup != nil {
+		t.Fatalf("Expected unset fields to be omitted, given: %#v", out)
+	}
+	if _, ok := flattenContainerSecurityContext(out)[0].(map[string]interface{})["allow_privilege_escalation"]; ok {
+		t.Fatal("Expected unset privilege escalation to be left unset")
+	}
+}
+
+func TestContainerVolumesRoundTrip(t *testing.T) {
+	bidirectional := v1.MountPropagationBidirectional
+	mounts := []v1.VolumeMount{
+		{Name: "data", MountPath: "/data", MountPropagation: &bidirectional},
+		{Name: "config", MountPath: "/etc/app", ReadOnly: true},
+	}
+	flattenedMounts, err := flattenContainerVolumeMounts(mounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outMounts, err := expandContainerVolumeMounts(flattenedMounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(outMounts, mounts) {
+		t.Fatalf("Unexpected volume mounts.\nExpected: %#v\nGiven:    %#v", mounts, outMounts)
+	}
+
+	devices := []v1.VolumeDevice{
+		{Name: "block", DevicePath: "/dev/xvda"},
+	}
+	outDevices := expandContainerVolumeDevices(flattenContainerVolumeDevices(devices))
+	if !reflect.DeepEqual(outDevices, devices) {
+		t.Fatalf("Unexpected volume devices.\nExpected: %#v\nGiven:    %#v", devices, outDevices)
+	}
+}
+
+func TestMigrateStateResourceRequirements(t *testing.T) {
+	is := &terraform.InstanceState{
+		Attributes: map[string]string{
+			"spec.0.template.0.spec.0.container.#":                                  "1",
+			"spec.0.template.0.spec.0.container.0.resources.#":                      "1",
+			"spec.0.template.0.spec.0.container.0.resources.0.limits.#":             "1",
+			"spec.0.template.0.spec.0.container.0.resources.0.limits.0.cpu":         "500m",
+			"spec.0.template.0.spec.0.container.0.resources.0.limits.0.memory":      "512Mi",
+			"spec.0.template.0.spec.0.container.0.resources.0.requests.#":           "1",
+			"spec.0.template.0.spec.0.container.0.resources.0.requests.0.cpu":       "250m",
+			"spec.0.template.0.spec.0.container.0.resources.0.requests.0.memory":    "",
+			"spec.0.template.0.spec.0.init_container.0.resources.0.limits.0.memory": "64Mi",
+			"spec.0.template.0.spec.0.init_container.0.resources.0.limits.#":        "1",
+		},
+	}
+
+	expected := map[string]string{
+		"spec.0.template.0.spec.0.container.#":                                "1",
+		"spec.0.template.0.spec.0.container.0.resources.#":                    "1",
+		"spec.0.template.0.spec.0.container.0.resources.0.limits.%":           "2",
+		"spec.0.template.0.spec.0.container.0.resources.0.limits.cpu":         "500m",
+		"spec.0.template.0.spec.0.container.0.resources.0.limits.memory":      "512Mi",
+		"spec.0.template.0.spec.0.container.0.resources.0.requests.%":         "1",
+		"spec.0.template.0.spec.0.container.0.resources.0.requests.cpu":       "250m",
+		"spec.0.template.0.spec.0.init_container.0.resources.0.limits.%":      "1",
+		"spec.0.template.0.spec.0.init_container.0.resources.0.limits.memory": "64Mi",
+	}
+
+	migrateStateResourceRequirements(is)
+	if !reflect.DeepEqual(is.Attributes, expected) {
+		t.Fatalf("Unexpected attributes after migration.\nExpected: %#v\nGiven:    %#v", expected, is.Attributes)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -14,7 +13,7 @@ func flattenDaemonSetSpec(in appsv1.DaemonSetSpec, d *schema.ResourceData) ([]in
 	att := make(map[string]interface{})
 	att["min_ready_seconds"] = in.MinReadySeconds
 
-	att["selector"] = in.Selector.MatchLabels
+	flattenWorkloadSelector(in.Selector, att, d)
 	att["strategy"] = flattenDaemonSetStrategy(in.UpdateStrategy)
 	// podSpec, err := flattenPodSpec(in.Template.Spec)
 	// if err != nil {
@@ -23,7 +22,7 @@ func flattenDaemonSetSpec(in appsv1.DaemonSetSpec, d *schema.ResourceData) ([]in
 	// att["template"] = podSpec
 
 	templateMetadata := flattenMetadata(in.Template.ObjectMeta, d, "spec.0.template.0.")
-	podSpec, err := flattenPodSpec(in.Template.Spec)
+	podSpec, err := flattenPodSpec(in.Template.Spec, d, "spec.0.template.0.spec.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +60,7 @@ func expandDaemonSetSpec(deployment []interface{}) (appsv1.DaemonSetSpec, error)
 	}
 	in := deployment[0].(map[string]interface{})
 	obj.MinReadySeconds = int32(in["min_ready_seconds"].(int))
-	if v, ok := in["selector"]; ok {
-		obj.Selector = &metav1.LabelSelector{
-			MatchLabels: expandStringMap(v.(map[string]interface{})),
-		}
-	}
+	obj.Selector = expandWorkloadSelector(in)
 	obj.UpdateStrategy = expandDaemonSetStrategy(in["strategy"].([]interface{}))
 
 	for _, v := range in["template"].([]interface{}) {
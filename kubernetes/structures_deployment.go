@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	appsv1 "k8s.io/api/apps/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -32,11 +31,11 @@ func flattenDeploymentSpec(in appsv1.DeploymentSpec, d *schema.ResourceData) ([]
 		att["revision_history_limit"] = 10
 	}
 
-	att["selector"] = in.Selector.MatchLabels
+	flattenWorkloadSelector(in.Selector, att, d)
 	att["strategy"] = flattenDeploymentStrategy(in.Strategy)
 
 	templateMetadata := flattenMetadata(in.Template.ObjectMeta, d, "spec.0.template.0.")
-	podSpec, err := flattenPodSpec(in.Template.Spec)
+	podSpec, err := flattenPodSpec(in.Template.Spec, d, "spec.0.template.0.spec.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -91,9 +90,7 @@ func expandDeploymentSpec(deployment []interface{}) (appsv1.DeploymentSpec, erro
 		obj.RevisionHistoryLimit = ptrToInt32(int32(in["revision_history_limit"].(int)))
 	}
 
-	obj.Selector = &metav1.LabelSelector{
-		MatchLabels: expandStringMap(in["selector"].(map[string]interface{})),
-	}
+	obj.Selector = expandWorkloadSelector(in)
 
 	for _, v := range in["template"].([]interface{}) {
 		template := v.(map[string]interface{})
@@ -12,7 +12,9 @@ import (
 
 // Flatteners
 
-func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
+// flattenPodSpec flattens a pod spec, prefix is the path of the pod spec
+// block in d (e.g. spec.0.template.0.spec.0.)
+func flattenPodSpec(in v1.PodSpec, d *schema.ResourceData, prefix string) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	if in.ActiveDeadlineSeconds != nil {
 		att["active_deadline_seconds"] = *in.ActiveDeadlineSeconds
@@ -22,7 +24,7 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 		att["affinity"] = flattenAffinity(in.Affinity)
 	}
 
-	containers, err := flattenContainers(in.Containers)
+	containers, err := flattenContainers(in.Containers, d, prefix+"container")
 	if err != nil {
 		return nil, err
 	}
@@ -36,12 +38,16 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	att["host_network"] = in.HostNetwork
 	att["host_pid"] = in.HostPID
 
+	if len(in.HostAliases) > 0 {
+		att["host_aliases"] = flattenHostAliases(in.HostAliases)
+	}
+
 	if in.Hostname != "" {
 		att["hostname"] = in.Hostname
 	}
 	att["image_pull_secrets"] = flattenLocalObjectReferenceArray(in.ImagePullSecrets)
 
-	initContainers, err := flattenContainers(in.InitContainers)
+	initContainers, err := flattenContainers(in.InitContainers, d, prefix+"init_container")
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +62,9 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	if in.PriorityClassName != "" {
 		att["priority_class_name"] = in.PriorityClassName
 	}
+	if len(in.ReadinessGates) > 0 {
+		att["readiness_gate"] = flattenReadinessGates(in.ReadinessGates)
+	}
 	if in.RestartPolicy != "" {
 		att["restart_policy"] = in.RestartPolicy
 	}
@@ -71,6 +80,10 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 		att["automount_service_account_token"] = *in.AutomountServiceAccountToken
 	}
 
+	if in.ShareProcessNamespace != nil {
+		att["share_process_namespace"] = *in.ShareProcessNamespace
+	}
+
 	if in.Subdomain != "" {
 		att["subdomain"] = in.Subdomain
 	}
@@ -114,13 +127,38 @@ func flattenDNSConfig(in *v1.PodDNSConfig) interface{} {
 	return nil
 }
 
-func flattenPodTemplateSpec(in v1.PodTemplateSpec, d *schema.ResourceData) ([]interface{}, error) {
+func flattenHostAliases(in []v1.HostAlias) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		hostnames := make([]interface{}, len(v.Hostnames))
+		for j, h := range v.Hostnames {
+			hostnames[j] = h
+		}
+		att[i] = map[string]interface{}{
+			"hostnames": hostnames,
+			"ip":        v.IP,
+		}
+	}
+	return att
+}
+
+func flattenReadinessGates(in []v1.PodReadinessGate) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"condition_type": string(v.ConditionType),
+		}
+	}
+	return att
+}
+
+func flattenPodTemplateSpec(in v1.PodTemplateSpec, d *schema.ResourceData, prefix string) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
 	meta := flattenMetadata(in.ObjectMeta, d)
 	att["metadata"] = meta
 
-	podSpec, err := flattenPodSpec(in.Spec)
+	podSpec, err := flattenPodSpec(in.Spec, d, prefix+"spec.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +173,10 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 		att["fs_group"] = *in.FSGroup
 	}
 
+	if in.RunAsGroup != nil {
+		att["run_as_group"] = *in.RunAsGroup
+	}
+
 	if in.RunAsNonRoot != nil {
 		att["run_as_non_root"] = *in.RunAsNonRoot
 	}
@@ -151,6 +193,9 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	if in.SELinuxOptions != nil {
 		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
 	}
+	if len(in.Sysctls) > 0 {
+		att["sysctl"] = flattenSysctls(in.Sysctls)
+	}
 
 	if len(att) > 0 {
 		return []interface{}{att}
@@ -225,6 +270,9 @@ func flattenVolumes(volumes []v1.Volume) ([]interface{}, error) {
 		if v.Secret != nil {
 			obj["secret"] = flattenSecretVolumeSource(v.Secret)
 		}
+		if v.Projected != nil {
+			obj["projected"] = flattenProjectedVolumeSource(v.Projected)
+		}
 		if v.GCEPersistentDisk != nil {
 			obj["gce_persistent_disk"] = flattenGCEPersistentDiskVolumeSource(v.GCEPersistentDisk)
 		}
@@ -397,6 +445,66 @@ func flattenSecretVolumeSource(in *v1.SecretVolumeSource) []interface{} {
 	return []interface{}{att}
 }
 
+func flattenProjectedVolumeSource(in *v1.ProjectedVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = int(*in.DefaultMode)
+	}
+	sources := make([]interface{}, len(in.Sources))
+	for i, v := range in.Sources {
+		m := map[string]interface{}{}
+		if v.Secret != nil {
+			m["secret"] = []interface{}{flattenProjectedKeys(v.Secret.Name, v.Secret.Items, v.Secret.Optional)}
+		}
+		if v.ConfigMap != nil {
+			m["config_map"] = []interface{}{flattenProjectedKeys(v.ConfigMap.Name, v.ConfigMap.Items, v.ConfigMap.Optional)}
+		}
+		if v.DownwardAPI != nil {
+			dapi := map[string]interface{}{}
+			if len(v.DownwardAPI.Items) > 0 {
+				dapi["items"] = flattenDownwardAPIVolumeFile(v.DownwardAPI.Items)
+			}
+			m["downward_api"] = []interface{}{dapi}
+		}
+		if v.ServiceAccountToken != nil {
+			token := map[string]interface{}{
+				"audience": v.ServiceAccountToken.Audience,
+				"path":     v.ServiceAccountToken.Path,
+			}
+			if v.ServiceAccountToken.ExpirationSeconds != nil {
+				token["expiration_seconds"] = int(*v.ServiceAccountToken.ExpirationSeconds)
+			}
+			m["service_account_token"] = []interface{}{token}
+		}
+		sources[i] = m
+	}
+	att["sources"] = sources
+	return []interface{}{att}
+}
+
+func flattenProjectedKeys(name string, keys []v1.KeyToPath, optional *bool) map[string]interface{} {
+	att := map[string]interface{}{
+		"name": name,
+	}
+	if len(keys) > 0 {
+		items := make([]interface{}, len(keys))
+		for i, v := range keys {
+			m := map[string]interface{}{}
+			m["key"] = v.Key
+			if v.Mode != nil {
+				m["mode"] = int(*v.Mode)
+			}
+			m["path"] = v.Path
+			items[i] = m
+		}
+		att["items"] = items
+	}
+	if optional != nil {
+		att["optional"] = *optional
+	}
+	return att
+}
+
 // Expanders
 
 func expandPodTemplateSpec(template map[string]interface{}) (v1.PodTemplateSpec, error) {
@@ -416,6 +524,17 @@ func expandPodTemplateSpec(template map[string]interface{}) (v1.PodTemplateSpec,
 	return obj, nil
 }
 
+func flattenSysctls(in []v1.Sysctl) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"name":  v.Name,
+			"value": v.Value,
+		}
+	}
+	return att
+}
+
 func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 	obj := v1.PodSpec{}
 	if len(p) == 0 || p[0] == nil {
@@ -463,6 +582,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.HostPID = v.(bool)
 	}
 
+	if v, ok := in["host_aliases"].([]interface{}); ok && len(v) > 0 {
+		obj.HostAliases = expandHostAliases(v)
+	}
+
 	if v, ok := in["hostname"]; ok {
 		obj.Hostname = v.(string)
 	}
@@ -498,6 +621,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.PriorityClassName = v.(string)
 	}
 
+	if v, ok := in["readiness_gate"].([]interface{}); ok && len(v) > 0 {
+		obj.ReadinessGates = expandReadinessGates(v)
+	}
+
 	if v, ok := in["restart_policy"].(string); ok {
 		obj.RestartPolicy = v1.RestartPolicy(v)
 	}
@@ -514,6 +641,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.AutomountServiceAccountToken = ptrToBool(v.(bool))
 	}
 
+	if v, ok := in["share_process_namespace"].(bool); ok && v {
+		obj.ShareProcessNamespace = ptrToBool(v)
+	}
+
 	if v, ok := in["subdomain"].(string); ok {
 		obj.Subdomain = v
 	}
@@ -551,6 +682,31 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 	return obj, nil
 }
 
+func expandHostAliases(l []interface{}) []v1.HostAlias {
+	obj := make([]v1.HostAlias, len(l))
+	for i, h := range l {
+		in := h.(map[string]interface{})
+		obj[i] = v1.HostAlias{
+			IP: in["ip"].(string),
+		}
+		if v, ok := in["hostnames"].([]interface{}); ok {
+			obj[i].Hostnames = expandStringSlice(v)
+		}
+	}
+	return obj
+}
+
+func expandReadinessGates(l []interface{}) []v1.PodReadinessGate {
+	obj := make([]v1.PodReadinessGate, len(l))
+	for i, g := range l {
+		in := g.(map[string]interface{})
+		obj[i] = v1.PodReadinessGate{
+			ConditionType: v1.PodConditionType(in["condition_type"].(string)),
+		}
+	}
+	return obj
+}
+
 func expandDNSConfig(l []interface{}) *v1.PodDNSConfig {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -589,6 +745,9 @@ func expandPodSecurityContext(l []interface{}) *v1.PodSecurityContext {
 	if v, ok := in["fs_group"].(int); ok {
 		obj.FSGroup = ptrToInt64(int64(v))
 	}
+	if v, ok := in["run_as_group"].(int); ok && v > 0 {
+		obj.RunAsGroup = ptrToInt64(int64(v))
+	}
 	if v, ok := in["run_as_non_root"].(bool); ok {
 		obj.RunAsNonRoot = ptrToBool(v)
 	}
@@ -603,6 +762,22 @@ func expandPodSecurityContext(l []interface{}) *v1.PodSecurityContext {
 		obj.SELinuxOptions = expandSeLinuxOptions(v)
 	}
 
+	if v, ok := in["sysctl"].([]interface{}); ok && len(v) > 0 {
+		obj.Sysctls = expandSysctls(v)
+	}
+
+	return obj
+}
+
+func expandSysctls(l []interface{}) []v1.Sysctl {
+	obj := make([]v1.Sysctl, len(l))
+	for i, s := range l {
+		in := s.(map[string]interface{})
+		obj[i] = v1.Sysctl{
+			Name:  in["name"].(string),
+			Value: in["value"].(string),
+		}
+	}
 	return obj
 }
 
@@ -788,6 +963,66 @@ func expandSecretVolumeSource(l []interface{}) *v1.SecretVolumeSource {
 	return obj
 }
 
+func expandProjectedVolumeSource(l []interface{}) (*v1.ProjectedVolumeSource, error) {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.ProjectedVolumeSource{}, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.ProjectedVolumeSource{
+		DefaultMode: ptrToInt32(int32(in["default_mode"].(int))),
+	}
+
+	sources, _ := in["sources"].([]interface{})
+	obj.Sources = make([]v1.VolumeProjection, len(sources))
+	for i, c := range sources {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := m["secret"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			p := v[0].(map[string]interface{})
+			obj.Sources[i].Secret = &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: p["name"].(string)},
+				Optional:             ptrToBool(p["optional"].(bool)),
+			}
+			if items, ok := p["items"].([]interface{}); ok && len(items) > 0 {
+				obj.Sources[i].Secret.Items = expandKeyPath(items)
+			}
+		}
+		if v, ok := m["config_map"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			p := v[0].(map[string]interface{})
+			obj.Sources[i].ConfigMap = &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: p["name"].(string)},
+				Optional:             ptrToBool(p["optional"].(bool)),
+			}
+			if items, ok := p["items"].([]interface{}); ok && len(items) > 0 {
+				obj.Sources[i].ConfigMap.Items = expandKeyPath(items)
+			}
+		}
+		if v, ok := m["downward_api"].([]interface{}); ok && len(v) > 0 {
+			obj.Sources[i].DownwardAPI = &v1.DownwardAPIProjection{}
+			if p, ok := v[0].(map[string]interface{}); ok {
+				if items, ok := p["items"].([]interface{}); ok && len(items) > 0 {
+					var err error
+					obj.Sources[i].DownwardAPI.Items, err = expandDownwardAPIVolumeFile(items)
+					if err != nil {
+						return obj, err
+					}
+				}
+			}
+		}
+		if v, ok := m["service_account_token"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			p := v[0].(map[string]interface{})
+			obj.Sources[i].ServiceAccountToken = &v1.ServiceAccountTokenProjection{
+				Audience:          p["audience"].(string),
+				ExpirationSeconds: ptrToInt64(int64(p["expiration_seconds"].(int))),
+				Path:              p["path"].(string),
+			}
+		}
+	}
+	return obj, nil
+}
+
 func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 	if len(volumes) == 0 {
 		return []v1.Volume{}, nil
@@ -828,6 +1063,13 @@ func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 		if value, ok := m["secret"].([]interface{}); ok && len(value) > 0 {
 			vl[i].Secret = expandSecretVolumeSource(value)
 		}
+		if value, ok := m["projected"].([]interface{}); ok && len(value) > 0 {
+			var err error
+			vl[i].Projected, err = expandProjectedVolumeSource(value)
+			if err != nil {
+				return vl, err
+			}
+		}
 		if v, ok := m["gce_persistent_disk"].([]interface{}); ok && len(v) > 0 {
 			vl[i].GCEPersistentDisk = expandGCEPersistentDiskVolumeSource(v)
 		}
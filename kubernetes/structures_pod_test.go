@@ -0,0 +1,180 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+)
+
+func TestProjectedVolumeSourceRoundTrip(t *testing.T) {
+	cases := []*v1.ProjectedVolumeSource{
+		{
+			DefaultMode: ptrToInt32(0644),
+			Sources: []v1.VolumeProjection{
+				{
+					ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+						Audience:          "vault",
+						ExpirationSeconds: ptrToInt64(7200),
+						Path:              "token",
+					},
+				},
+			},
+		},
+		{
+			DefaultMode: ptrToInt32(0440),
+			Sources: []v1.VolumeProjection{
+				{
+					Secret: &v1.SecretProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "credentials"},
+						Items: []v1.KeyToPath{
+							{Key: "username", Path: "auth/username", Mode: ptrToInt32(0400)},
+						},
+						Optional: ptrToBool(false),
+					},
+				},
+				{
+					ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "settings"},
+						Optional:             ptrToBool(true),
+					},
+				},
+				{
+					DownwardAPI: &v1.DownwardAPIProjection{
+						Items: []v1.DownwardAPIVolumeFile{
+							{
+								Path: "labels",
+								FieldRef: &v1.ObjectFieldSelector{
+									APIVersion: "v1",
+									FieldPath:  "metadata.labels",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		flattened := flattenProjectedVolumeSource(tc)
+		out, err := expandProjectedVolumeSource(flattened)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(out, tc) {
+			t.Fatalf("Unexpected projected volume after round trip.\nExpected: %#v\nGiven:    %#v", tc, out)
+		}
+	}
+}
+
+func TestExpandVolumesProjected(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"name": "token",
+			"projected": []interface{}{
+				map[string]interface{}{
+					"default_mode": 0644,
+					"sources": []interface{}{
+						map[string]interface{}{
+							"service_account_token": []interface{}{
+								map[string]interface{}{
+									"audience":           "sts.amazonaws.com",
+									"expiration_seconds": 86400,
+									"path":               "token",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	volumes, err := expandVolumes(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(volumes) != 1 || volumes[0].Projected == nil {
+		t.Fatalf("Expected a projected volume, given: %#v", volumes)
+	}
+	token := volumes[0].Projected.Sources[0].ServiceAccountToken
+	if token == nil || token.Audience != "sts.amazonaws.com" || *token.ExpirationSeconds != 86400 {
+		t.Fatalf("Unexpected service account token projection: %#v", token)
+	}
+
+	flattened, err := flattenVolumes(volumes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := flattened[0].(map[string]interface{})["projected"]; !ok {
+		t.Fatalf("Expected the projected volume to be flattened, given: %#v", flattened)
+	}
+}
+
+func TestPodSpecRoundTrip(t *testing.T) {
+	in := v1.PodSpec{
+		HostAliases: []v1.HostAlias{
+			{IP: "10.0.0.1", Hostnames: []string{"db.local", "db"}},
+		},
+		ReadinessGates: []v1.PodReadinessGate{
+			{ConditionType: "www.example.com/feature-1"},
+		},
+		SecurityContext: &v1.PodSecurityContext{
+			RunAsGroup: ptrToInt64(2000),
+			Sysctls: []v1.Sysctl{
+				{Name: "net.core.somaxconn", Value: "1024"},
+			},
+		},
+		ShareProcessNamespace: ptrToBool(true),
+	}
+
+	flattened, err := flattenPodSpec(in, nil, "spec.0.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := expandPodSpec(flattened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out.HostAliases, in.HostAliases) {
+		t.Fatalf("Unexpected host aliases.\nExpected: %#v\nGiven:    %#v", in.HostAliases, out.HostAliases)
+	}
+	if !reflect.DeepEqual(out.ReadinessGates, in.ReadinessGates) {
+		t.Fatalf("Unexpected readiness gates.\nExpected: %#v\nGiven:    %#v", in.ReadinessGates, out.ReadinessGates)
+	}
+	if out.SecurityContext == nil || !reflect.DeepEqual(out.SecurityContext.Sysctls, in.SecurityContext.Sysctls) {
+		t.Fatalf("Unexpected security context.\nExpected: %#v\nGiven:    %#v", in.SecurityContext, out.SecurityContext)
+	}
+	securityContext := flattened[0].(map[string]interface{})["security_context"].([]interface{})[0].(map[string]interface{})
+	if securityContext["run_as_group"] != int64(2000) {
+		t.Fatalf("Unexpected run_as_group: %#v", securityContext["run_as_group"])
+	}
+	if !reflect.DeepEqual(out.ShareProcessNamespace, in.ShareProcessNamespace) {
+		t.Fatalf("Expected the process namespace to be shared, given: %#v", out.ShareProcessNamespace)
+	}
+}
+
+func TestExpandPodSecurityContextUnsetGroup(t *testing.T) {
+	// A security context with only sysctls must not run the pod as GID 0
+	out := expandPodSecurityContext([]interface{}{
+		map[string]interface{}{
+			"run_as_group": 0,
+			"sysctl": []interface{}{
+				map[string]interface{}{"name": "net.core.somaxconn", "value": "1024"},
+			},
+		},
+	})
+	if out.RunAsGroup != nil {
+		t.Fatalf("Expected unset run_as_group to be left to the runtime, given: %#v", *out.RunAsGroup)
+	}
+
+	out = expandPodSecurityContext([]interface{}{
+		map[string]interface{}{
+			"run_as_group": 2000,
+		},
+	})
+	if out.RunAsGroup == nil || *out.RunAsGroup != 2000 {
+		t.Fatalf("Unexpected run_as_group: %#v", out.RunAsGroup)
+	}
+}
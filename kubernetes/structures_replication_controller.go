@@ -1,11 +1,12 @@
 package kubernetes
 
 import (
+	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func flattenReplicationControllerSpec(in v1.ReplicationControllerSpec) ([]interface{}, error) {
+func flattenReplicationControllerSpec(in v1.ReplicationControllerSpec, d *schema.ResourceData) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	att["min_ready_seconds"] = in.MinReadySeconds
 
@@ -14,7 +15,7 @@ func flattenReplicationControllerSpec(in v1.ReplicationControllerSpec) ([]interf
 	}
 
 	att["selector"] = in.Selector
-	podSpec, err := flattenPodSpec(in.Template.Spec)
+	podSpec, err := flattenPodSpec(in.Template.Spec, d, "spec.0.template.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func flattenStatefulSetSpec(in appsv1.StatefulSetSpec, d *schema.ResourceData) ([]interface{}, error) {
@@ -20,11 +19,11 @@ func flattenStatefulSetSpec(in appsv1.StatefulSetSpec, d *schema.ResourceData) (
 		att["revision_history_limit"] = *in.RevisionHistoryLimit
 	}
 	att["service_name"] = in.ServiceName
-	att["selector"] = in.Selector.MatchLabels
+	flattenWorkloadSelector(in.Selector, att, d)
 	att["update_strategy"] = flattenStatefulSetUpdateStrategy(in.UpdateStrategy, d)
 
 	templateMetadata := flattenMetadata(in.Template.ObjectMeta, d, "spec.0.template.0.")
-	podSpec, err := flattenPodSpec(in.Template.Spec)
+	podSpec, err := flattenPodSpec(in.Template.Spec, d, "spec.0.template.0.spec.0.")
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +84,7 @@ func expandStatefulSetSpec(statefulSet []interface{}) (appsv1.StatefulSetSpec, e
 	}
 
 	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
-	obj.Selector = &metav1.LabelSelector{
-		MatchLabels: expandStringMap(in["selector"].(map[string]interface{})),
-	}
+	obj.Selector = expandWorkloadSelector(in)
 	obj.ServiceName = in["service_name"].(string)
 
 	for _, v := range in["template"].([]interface{}) {
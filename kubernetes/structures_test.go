@@ -2,7 +2,10 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func TestIsInternalKey(t *testing.T) {
@@ -30,3 +33,27 @@ func TestIsInternalKey(t *testing.T) {
 		})
 	}
 }
+
+func TestWithResourceVersionTest(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesConfigMap().Schema, map[string]interface{}{})
+	d.SetId("default/foo")
+	err := d.Set("metadata", []interface{}{map[string]interface{}{"name": "foo", "resource_version": "1234"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops := PatchOperations{&RemoveOperation{Path: "/metadata/labels/foo"}}
+
+	out := withResourceVersionTest(d, &kubernetesProvider{}, ops)
+	if len(out) != 1 {
+		t.Fatalf("Expected no test operation when disabled, given: %#v", out)
+	}
+
+	out = withResourceVersionTest(d, &kubernetesProvider{optimisticConcurrency: true}, ops)
+	expected := []PatchOperation{
+		&TestOperation{Path: "/metadata/resourceVersion", Value: "1234"},
+		&RemoveOperation{Path: "/metadata/labels/foo"},
+	}
+	if !reflect.DeepEqual([]PatchOperation(out), expected) {
+		t.Fatalf("Expected %#v, given: %#v", expected, out)
+	}
+}
@@ -0,0 +1,201 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+const (
+	updateStrategyJSONPatch       = "json_patch"
+	updateStrategyStrategicMerge  = "strategic_merge"
+	updateStrategyServerSideApply = "server_side_apply"
+
+	// fieldManager owns the fields applied with server-side apply
+	fieldManager = "terraform"
+
+	applyPatchType pkgApi.PatchType = "application/apply-patch+yaml"
+
+	causeTypeFieldManagerConflict metav1.CauseType = "FieldManagerConflict"
+)
+
+var updateStrategies = []string{
+	updateStrategyJSONPatch,
+	updateStrategyStrategicMerge,
+	updateStrategyServerSideApply,
+}
+
+// expandObjectFunc expands the API object of a resource, get returns either
+// the values in state or the planned values
+type expandObjectFunc func(get func(key string) interface{}) (runtime.Object, error)
+
+// updatePatch is the body of an update in the update strategy of the provider
+type updatePatch struct {
+	Type   pkgApi.PatchType
+	Data   []byte
+	Params map[string]string
+}
+
+// buildUpdatePatch builds the update of a resource in the update strategy
+// configured on the provider: the given JSON patch operations, a strategic
+// merge patch between the objects in state and planned, or the whole planned
+// object applied server-side. apiVersion and kind are only used by
+// server-side apply.
+func buildUpdatePatch(d *schema.ResourceData, meta interface{}, ops PatchOperations, apiVersion, kind string, expand expandObjectFunc) (*updatePatch, error) {
+	kp := meta.(*kubernetesProvider)
+
+	switch kp.updateStrategy {
+	case updateStrategyStrategicMerge:
+		oldObj, err := expand(func(key string) interface{} {
+			o, _ := d.GetChange(key)
+			return o
+		})
+		if err != nil {
+			return nil, err
+		}
+		newObj, err := expand(d.Get)
+		if err != nil {
+			return nil, err
+		}
+		// The resource version is only sent as a precondition
+		clearResourceVersion(oldObj)
+		clearResourceVersion(newObj)
+
+		data, err := createStrategicMergePatch(oldObj, newObj)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate strategic merge patch: %s", err)
+		}
+		if kp.optimisticConcurrency {
+			data, err = withResourceVersionPrecondition(data, d.Get("metadata.0.resource_version").(string))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &updatePatch{
+			Type: pkgApi.StrategicMergePatchType,
+			Data: data,
+		}, nil
+
+	case updateStrategyServerSideApply:
+		obj, err := expand(d.Get)
+		if err != nil {
+			return nil, err
+		}
+		if !kp.optimisticConcurrency {
+			clearResourceVersion(obj)
+		}
+		// Apply requires the type of the object to be set
+		obj.GetObjectKind().SetGroupVersionKind(k8sschema.FromAPIVersionAndKind(apiVersion, kind))
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to marshal %s: %s", kind, err)
+		}
+		params := map[string]string{
+			"fieldManager": fieldManager,
+		}
+		if kp.forceConflicts {
+			params["force"] = "true"
+		}
+		return &updatePatch{
+			Type:   applyPatchType,
+			Data:   data,
+			Params: params,
+		}, nil
+	}
+
+	data, err := withResourceVersionTest(d, meta, ops).MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	return &updatePatch{
+		Type: pkgApi.JSONPatchType,
+		Data: data,
+	}, nil
+}
+
+// apply sends the update of the named object to the given API group client
+// and decodes the resulting object into out
+func (p *updatePatch) apply(client restclient.Interface, resource, namespace, name string, out runtime.Object) error {
+	return p.request(client, resource, namespace, name).Do().Into(out)
+}
+
+// request builds the update of the named object, for group-versions whose
+// objects can't be decoded by the vendored client-go
+func (p *updatePatch) request(client restclient.Interface, resource, namespace, name string) *restclient.Request {
+	req := client.Patch(p.Type).
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Body(p.Data)
+	for k, v := range p.Params {
+		req = req.Param(k, v)
+	}
+	return req
+}
+
+func (p *updatePatch) String() string {
+	return fmt.Sprintf("%s %s", p.Type, string(p.Data))
+}
+
+func clearResourceVersion(obj runtime.Object) {
+	if m, ok := obj.(metav1.Object); ok {
+		m.SetResourceVersion("")
+	}
+}
+
+// withResourceVersionPrecondition makes the API server reject a merge patch
+// with a conflict if the object was modified since the last refresh
+func withResourceVersionPrecondition(data []byte, resourceVersion string) ([]byte, error) {
+	if resourceVersion == "" {
+		return data, nil
+	}
+	patch := make(map[string]interface{})
+	err := json.Unmarshal(data, &patch)
+	if err != nil {
+		return nil, err
+	}
+	metadata, ok := patch["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		patch["metadata"] = metadata
+	}
+	metadata["resourceVersion"] = resourceVersion
+	return json.Marshal(patch)
+}
+
+// translateApplyConflictError explains the fields which couldn't be applied
+// because they are owned by other field managers
+func translateApplyConflictError(err error, s map[string]*schema.Schema) error {
+	statusErr, ok := err.(*errors.StatusError)
+	if !ok || !errors.IsConflict(statusErr) || statusErr.ErrStatus.Details == nil {
+		return err
+	}
+
+	conflicts := make([]string, 0)
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type != causeTypeFieldManagerConflict {
+			continue
+		}
+		path := translateFieldPath(strings.TrimPrefix(cause.Field, "."), s)
+		conflicts = append(conflicts, fmt.Sprintf("* %s: %s", path, cause.Message))
+	}
+	if len(conflicts) == 0 {
+		return err
+	}
+
+	log.Printf("[DEBUG] Server-side apply conflicts: %#v", statusErr.ErrStatus.Details.Causes)
+	return fmt.Errorf("%s %q has fields managed by other field managers:\n\n%s\n\n"+
+		"Remove these fields from the configuration or set force_conflicts in the provider "+
+		"configuration to take ownership of them", statusErr.ErrStatus.Details.Kind, statusErr.ErrStatus.Details.Name,
+		strings.Join(conflicts, "\n"))
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildUpdatePatch(t *testing.T) {
+	s := resourceKubernetesConfigMap().Schema
+	state := map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{
+				"name":             "foo",
+				"namespace":        "default",
+				"resource_version": "42",
+			},
+		},
+		"data": map[string]interface{}{"one": "1"},
+	}
+	d := schema.TestResourceDataRaw(t, s, state)
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	patch, err := buildUpdatePatch(d, &kubernetesProvider{}, ops, "v1", "ConfigMap", expandConfigMapObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Type != pkgApi.JSONPatchType || len(patch.Params) != 0 {
+		t.Fatalf("Expected a JSON patch by default, got: %s", patch)
+	}
+
+	kp := &kubernetesProvider{updateStrategy: updateStrategyServerSideApply}
+	patch, err = buildUpdatePatch(d, kp, ops, "v1", "ConfigMap", expandConfigMapObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Type != applyPatchType || patch.Params["fieldManager"] != fieldManager {
+		t.Fatalf("Expected an apply patch with the terraform field manager, got: %#v", patch)
+	}
+	if _, ok := patch.Params["force"]; ok {
+		t.Fatal("Expected conflicts not to be forced by default")
+	}
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal(patch.Data, &obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj["apiVersion"] != "v1" || obj["kind"] != "ConfigMap" {
+		t.Fatalf("Expected the type of the object to be set, got: %s", patch.Data)
+	}
+	if strings.Contains(string(patch.Data), "resourceVersion") {
+		t.Fatalf("Expected the resource version to be left out, got: %s", patch.Data)
+	}
+
+	kp = &kubernetesProvider{updateStrategy: updateStrategyServerSideApply, forceConflicts: true}
+	patch, err = buildUpdatePatch(d, kp, ops, "v1", "ConfigMap", expandConfigMapObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Params["force"] != "true" {
+		t.Fatalf("Expected conflicts to be forced, got: %#v", patch.Params)
+	}
+
+	kp = &kubernetesProvider{updateStrategy: updateStrategyStrategicMerge, optimisticConcurrency: true}
+	patch, err = buildUpdatePatch(d, kp, ops, "v1", "ConfigMap", expandConfigMapObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Type != pkgApi.StrategicMergePatchType || !strings.Contains(string(patch.Data), `"resourceVersion":"42"`) {
+		t.Fatalf("Expected a strategic merge patch with the resource version, got: %s", patch)
+	}
+}
+
+func TestTranslateApplyConflictError(t *testing.T) {
+	s := resourceKubernetesDeployment().Schema
+
+	conflict := &errors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Kind: "deployments",
+			Name: "foo",
+			Causes: []metav1.StatusCause{
+				{
+					Type:    causeTypeFieldManagerConflict,
+					Message: `conflict with "kubectl"`,
+					Field:   ".spec.replicas",
+				},
+			},
+		},
+		Message: `Apply failed with 1 conflict: conflict with "kubectl": .spec.replicas`,
+	}}
+
+	err := translateApplyConflictError(conflict, s)
+	if err == conflict {
+		t.Fatal("Expected apply conflicts to be explained")
+	}
+	if !strings.Contains(err.Error(), `* spec.0.replicas: conflict with "kubectl"`) || !strings.Contains(err.Error(), "force_conflicts") {
+		t.Fatalf("Expected the conflicting attributes to be listed, got: %s", err)
+	}
+
+	other := errors.NewConflict(k8sschema.GroupResource{Group: "apps", Resource: "deployments"}, "foo", fmt.Errorf("the object has been modified"))
+	if err := translateApplyConflictError(other, s); err != other {
+		t.Fatalf("Expected other conflicts to be returned as-is, got: %s", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/robfig/cron"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
@@ -142,6 +143,30 @@ func validatePositiveInteger(value interface{}, key string) (ws []string, es []e
 	return
 }
 
+// validateNonNegativeIntegerString validates integers kept in string
+// attributes so that 0 can be told apart from an unset attribute
+func validateNonNegativeIntegerString(value interface{}, key string) (ws []string, es []error) {
+	v, err := strconv.Atoi(value.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be an integer", key, value))
+		return
+	}
+	if v < 0 {
+		es = append(es, fmt.Errorf("%s must be greater than or equal to 0", key))
+	}
+	return
+}
+
+// validateBoolString validates booleans kept in string attributes so that
+// false can be told apart from an unset attribute
+func validateBoolString(value interface{}, key string) (ws []string, es []error) {
+	_, err := strconv.ParseBool(value.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be a boolean", key, value))
+	}
+	return
+}
+
 func validateDNSPolicy(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 	if v != "ClusterFirst" && v != "Default" {
@@ -169,6 +194,15 @@ func validateTerminationGracePeriodSeconds(value interface{}, key string) (ws []
 	return
 }
 
+func validateCronSchedule(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	// Same parser as used by the cron job controller
+	if _, err := cron.ParseStandard(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid cron schedule: %s", key, v, err))
+	}
+	return
+}
+
 func validateModeBits(value interface{}, key string) (ws []string, es []error) {
 	v := value.(int)
 	if v < 0 || v > 0777 {
@@ -177,6 +211,14 @@ func validateModeBits(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
+func validateIP(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, msg := range utilValidation.IsValidIP(v) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, msg))
+	}
+	return
+}
+
 func validateAttributeValueDoesNotContain(searchString string) schema.SchemaValidateFunc {
 	return func(v interface{}, k string) (ws []string, errors []error) {
 		input := v.(string)
@@ -25,3 +25,94 @@ func TestValidateModeBits(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateNonNegativeIntegerString(t *testing.T) {
+	validCases := []string{
+		"0", "5", "3600",
+	}
+	for _, v := range validCases {
+		_, es := validateNonNegativeIntegerString(v, "ttl_seconds_after_finished")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", v, es)
+		}
+	}
+
+	invalidCases := []string{
+		"-1", "1.5", "1h", "",
+	}
+	for _, v := range invalidCases {
+		_, es := validateNonNegativeIntegerString(v, "ttl_seconds_after_finished")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestValidateBoolString(t *testing.T) {
+	validCases := []string{
+		"true", "false", "1", "0",
+	}
+	for _, v := range validCases {
+		_, es := validateBoolString(v, "allow_privilege_escalation")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", v, es)
+		}
+		if n := normalizeBoolString(v); n != "true" && n != "false" {
+			t.Fatalf("Unexpected normalized value of %q: %q", v, n)
+		}
+	}
+
+	invalidCases := []string{
+		"yes", "2", "",
+	}
+	for _, v := range invalidCases {
+		_, es := validateBoolString(v, "allow_privilege_escalation")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestValidateCronSchedule(t *testing.T) {
+	validCases := []string{
+		"0 * * * *", "*/15 2-4 * * 1-5", "1 0 1 JAN *", "@hourly", "@daily", "@every 1h30m",
+	}
+	for _, schedule := range validCases {
+		_, es := validateCronSchedule(schedule, "schedule")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", schedule, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "* * * *", "0 0 * * * *", "61 * * * *", "@fortnightly", "every hour",
+	}
+	for _, schedule := range invalidCases {
+		_, es := validateCronSchedule(schedule, "schedule")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", schedule)
+		}
+	}
+}
+
+func TestValidateIP(t *testing.T) {
+	validCases := []string{
+		"127.0.0.1", "10.1.2.3", "::1", "fe80::1",
+	}
+	for _, ip := range validCases {
+		_, es := validateIP(ip, "ip")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", ip, es)
+		}
+	}
+
+	invalidCases := []string{
+		"", "foo.local", "10.1.2", "10.1.2.300",
+	}
+	for _, ip := range invalidCases {
+		_, es := validateIP(ip, "ip")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", ip)
+		}
+	}
+}
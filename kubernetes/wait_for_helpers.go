@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type waitForCondition struct {
+	Condition string
+	Status    string
+	Field     string
+	Value     string
+}
+
+func (c waitForCondition) String() string {
+	if c.Condition != "" {
+		return fmt.Sprintf("condition %s=%s", c.Condition, c.Status)
+	}
+	return fmt.Sprintf("field %s=%s", c.Field, c.Value)
+}
+
+// observe looks the condition up in the given object (as decoded from JSON)
+// and returns the observed value along with whether it matches
+func (c waitForCondition) observe(obj map[string]interface{}) (string, bool) {
+	if c.Condition != "" {
+		conditions, ok := lookupField(obj, "status.conditions").([]interface{})
+		if !ok {
+			return "", false
+		}
+		for _, v := range conditions {
+			cond, ok := v.(map[string]interface{})
+			if !ok || cond["type"] != c.Condition {
+				continue
+			}
+			status := fmt.Sprintf("%v", cond["status"])
+			return status, status == c.Status
+		}
+		return "", false
+	}
+
+	v := lookupField(obj, c.Field)
+	if v == nil {
+		return "", false
+	}
+	observed := fmt.Sprintf("%v", v)
+	return observed, observed == c.Value
+}
+
+// lookupField walks a dot-separated path through nested maps and lists,
+// numeric segments are treated as list indexes
+func lookupField(obj interface{}, path string) interface{} {
+	current := obj
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			current = v[part]
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			current = v[i]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+func expandWaitForConditions(l []interface{}) []waitForCondition {
+	conditions := make([]waitForCondition, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		conditions = append(conditions, waitForCondition{
+			Condition: in["condition"].(string),
+			Status:    in["status"].(string),
+			Field:     in["field"].(string),
+			Value:     in["value"].(string),
+		})
+	}
+	return conditions
+}
+
+// validateWaitForConditions is called from CustomizeDiff so that invalid
+// wait_for blocks are reported by the plan rather than after the object was
+// created or updated
+func validateWaitForConditions(l []interface{}) error {
+	for i, c := range expandWaitForConditions(l) {
+		if c.Condition == "" && c.Field == "" {
+			return fmt.Errorf("wait_for.%d: one of condition or field must be set", i)
+		}
+		if c.Condition != "" && c.Field != "" {
+			return fmt.Errorf("wait_for.%d: condition and field cannot be set together", i)
+		}
+	}
+	return nil
+}
+
+// waitForObjectConditions blocks until all wait_for conditions configured
+// on the resource are met by the object returned from read.
+// The last observed value of the first unmet condition is reported on timeout.
+func waitForObjectConditions(d *schema.ResourceData, timeoutKey, kind string, read func() (interface{}, error)) error {
+	conditions := expandWaitForConditions(d.Get("wait_for").([]interface{}))
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	return resource.Retry(d.Timeout(timeoutKey), func() *resource.RetryError {
+		obj, err := read()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		m := make(map[string]interface{})
+		err = Convert(obj, &m)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		for _, c := range conditions {
+			observed, ok := c.observe(m)
+			log.Printf("[DEBUG] %s %s: waiting for %s, observed %q", kind, d.Id(), c, observed)
+			if !ok {
+				if observed == "" {
+					observed = "<not set>"
+				}
+				return resource.RetryableError(fmt.Errorf("Waiting for %s %q to reach %s (last observed: %s)",
+					kind, d.Id(), c, observed))
+			}
+		}
+		return nil
+	})
+}
+
+// waitForLoadBalancer waits for the load balancer of a service or an ingress
+// to be assigned an IP or a hostname, read returns its current status
+func waitForLoadBalancer(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, kind string, timeout time.Duration, read func() (*api.LoadBalancerStatus, error)) error {
+	log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		status, err := read()
+		if err != nil {
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return resource.NonRetryableError(err)
+		}
+
+		log.Printf("[INFO] Received %s load balancer status: %#v", strings.ToLower(kind), status)
+		if len(status.Ingress) > 0 {
+			return nil
+		}
+
+		return resource.RetryableError(fmt.Errorf(
+			"Waiting for %s %q to assign IP/hostname for a load balancer", strings.ToLower(kind), buildId(metadata)))
+	})
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(conn, metadata, kind, 3)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWaitForConditionObserve(t *testing.T) {
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":         "Running",
+			"readyReplicas": float64(3),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False"},
+				map[string]interface{}{"type": "Complete", "status": "True"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Condition        waitForCondition
+		ExpectedObserved string
+		ExpectedMatch    bool
+	}{
+		{waitForCondition{Condition: "Complete", Status: "True"}, "True", true},
+		{waitForCondition{Condition: "Ready", Status: "True"}, "False", false},
+		{waitForCondition{Condition: "Failed", Status: "True"}, "", false},
+		{waitForCondition{Field: "status.phase", Value: "Running"}, "Running", true},
+		{waitForCondition{Field: "status.phase", Value: "Succeeded"}, "Running", false},
+		{waitForCondition{Field: "status.readyReplicas", Value: "3"}, "3", true},
+		{waitForCondition{Field: "status.conditions.1.type", Value: "Complete"}, "Complete", true},
+		{waitForCondition{Field: "status.conditions.5.type", Value: "Complete"}, "", false},
+		{waitForCondition{Field: "spec.replicas", Value: "1"}, "", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			observed, match := tc.Condition.observe(obj)
+			if observed != tc.ExpectedObserved {
+				t.Fatalf("Expected %s to observe %q, got %q", tc.Condition, tc.ExpectedObserved, observed)
+			}
+			if match != tc.ExpectedMatch {
+				t.Fatalf("Expected %s match to be %t", tc.Condition, tc.ExpectedMatch)
+			}
+		})
+	}
+}
+
+func TestExpandWaitForConditions(t *testing.T) {
+	valid := []interface{}{
+		map[string]interface{}{"condition": "Ready", "status": "True", "field": "", "value": ""},
+		map[string]interface{}{"condition": "", "status": "True", "field": "status.phase", "value": "Running"},
+	}
+	if err := validateWaitForConditions(valid); err != nil {
+		t.Fatal(err)
+	}
+	conditions := expandWaitForConditions(valid)
+	if len(conditions) != 2 {
+		t.Fatalf("Expected 2 conditions, got %d", len(conditions))
+	}
+
+	invalidCases := [][]interface{}{
+		{map[string]interface{}{"condition": "", "status": "True", "field": "", "value": ""}},
+		{map[string]interface{}{"condition": "Ready", "status": "True", "field": "status.phase", "value": "Running"}},
+	}
+	for _, tc := range invalidCases {
+		if err := validateWaitForConditions(tc); err == nil {
+			t.Fatalf("Expected %#v to be invalid", tc)
+		}
+	}
+}
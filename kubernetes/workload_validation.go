@@ -0,0 +1,165 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/config"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Restart policies accepted by the API server per workload kind
+var (
+	controllerRestartPolicies = []api.RestartPolicy{api.RestartPolicyAlways}
+	jobRestartPolicies        = []api.RestartPolicy{api.RestartPolicyOnFailure, api.RestartPolicyNever}
+)
+
+// validatePodTemplate catches mistakes in a pod template which the API server
+// would otherwise only reject in the middle of an apply.
+// The selector may be nil when it's generated by the server, extraVolumes
+// are volume names provided outside of the pod spec (e.g. volume claim templates).
+func validatePodTemplate(prefix string, template api.PodTemplateSpec, selector *metav1.LabelSelector, extraVolumes []string, restartPolicies []api.RestartPolicy) error {
+	var result *multierror.Error
+
+	if selector != nil {
+		err := validateSelectorMatchesLabels(selector, template.Labels)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s.metadata.0.labels: %s", prefix, err))
+		}
+	}
+
+	err := validatePodSpec(prefix+".spec.0", template.Spec, extraVolumes, restartPolicies)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validatePodSpec validates the restart policy (unless restartPolicies is empty)
+// and the names referenced within the pod spec
+func validatePodSpec(prefix string, spec api.PodSpec, extraVolumes []string, restartPolicies []api.RestartPolicy) error {
+	var result *multierror.Error
+
+	if len(restartPolicies) > 0 {
+		err := validatePodRestartPolicy(spec.RestartPolicy, restartPolicies)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s.restart_policy: %s", prefix, err))
+		}
+	}
+
+	for _, err := range validatePodSpecReferences(spec, extraVolumes) {
+		result = multierror.Append(result, fmt.Errorf("%s.%s", prefix, err))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func validateSelectorMatchesLabels(selector *metav1.LabelSelector, templateLabels map[string]string) error {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		// Defaulted from the template labels by the server
+		return nil
+	}
+	if len(templateLabels) == 0 {
+		// Labels may not be known until apply
+		return nil
+	}
+	for _, v := range templateLabels {
+		if !isKnownName(v) {
+			return nil
+		}
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector: %s", err)
+	}
+	if !s.Matches(labels.Set(templateLabels)) {
+		return fmt.Errorf("template labels %v do not match the selector %q", templateLabels, s.String())
+	}
+	return nil
+}
+
+func validatePodRestartPolicy(policy api.RestartPolicy, allowed []api.RestartPolicy) error {
+	if policy == "" {
+		return nil
+	}
+	names := make([]string, len(allowed))
+	for i, p := range allowed {
+		if p == policy {
+			return nil
+		}
+		names[i] = string(p)
+	}
+	return fmt.Errorf("%q is not supported, must be one of: %s", policy, strings.Join(names, ", "))
+}
+
+// validatePodSpecReferences checks that container, port and volume names are
+// unique and that all volume mounts refer to a defined volume.
+// Names not known until apply are skipped.
+func validatePodSpecReferences(spec api.PodSpec, extraVolumes []string) []error {
+	errs := make([]error, 0)
+
+	volumes := make(map[string]bool)
+	volumesKnown := true
+	for i, v := range spec.Volumes {
+		if !isKnownName(v.Name) {
+			volumesKnown = false
+			continue
+		}
+		if volumes[v.Name] {
+			errs = append(errs, fmt.Errorf("volume.%d.name: duplicate volume name %q", i, v.Name))
+		}
+		volumes[v.Name] = true
+	}
+	for _, name := range extraVolumes {
+		volumes[name] = true
+	}
+
+	containerNames := make(map[string]bool)
+	check := func(key string, containers []api.Container) {
+		for i, c := range containers {
+			if isKnownName(c.Name) {
+				if containerNames[c.Name] {
+					errs = append(errs, fmt.Errorf("%s.%d.name: duplicate container name %q", key, i, c.Name))
+				}
+				containerNames[c.Name] = true
+			}
+
+			portNames := make(map[string]bool)
+			for j, p := range c.Ports {
+				if !isKnownName(p.Name) {
+					continue
+				}
+				if portNames[p.Name] {
+					errs = append(errs, fmt.Errorf("%s.%d.port.%d.name: duplicate port name %q in container %q", key, i, j, p.Name, c.Name))
+				}
+				portNames[p.Name] = true
+			}
+
+			for j, m := range c.VolumeMounts {
+				if !volumesKnown || !isKnownName(m.Name) || volumes[m.Name] {
+					continue
+				}
+				errs = append(errs, fmt.Errorf("%s.%d.volume_mount.%d.name: volume %q is not defined", key, i, j, m.Name))
+			}
+		}
+	}
+	check("init_container", spec.InitContainers)
+	check("container", spec.Containers)
+
+	if len(errs) > 0 {
+		log.Printf("[DEBUG] Pod spec validation failed: %v", errs)
+	}
+	return errs
+}
+
+// isKnownName reports whether a name from the plan can be validated,
+// interpolated values are empty or unknown until apply
+func isKnownName(name string) bool {
+	return name != "" && name != config.UnknownVariableValue
+}
@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateSelectorMatchesLabels(t *testing.T) {
+	cases := []struct {
+		Selector *metav1.LabelSelector
+		Labels   map[string]string
+		Valid    bool
+	}{
+		{
+			Selector: &metav1.LabelSelector{},
+			Labels:   map[string]string{"app": "foo"},
+			Valid:    true,
+		},
+		{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Labels:   map[string]string{"app": "foo", "tier": "web"},
+			Valid:    true,
+		},
+		{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Labels:   map[string]string{"app": "bar"},
+			Valid:    false,
+		},
+		{
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"web", "cache"}},
+				},
+			},
+			Labels: map[string]string{"tier": "db"},
+			Valid:  false,
+		},
+		{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Labels:   map[string]string{"app": config.UnknownVariableValue},
+			Valid:    true,
+		},
+		{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Labels:   map[string]string{},
+			Valid:    true,
+		},
+	}
+
+	for i, tc := range cases {
+		err := validateSelectorMatchesLabels(tc.Selector, tc.Labels)
+		if tc.Valid && err != nil {
+			t.Fatalf("case %d: expected to be valid: %s", i, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("case %d: expected to be invalid", i)
+		}
+	}
+}
+
+func TestValidatePodRestartPolicy(t *testing.T) {
+	if err := validatePodRestartPolicy(api.RestartPolicyNever, jobRestartPolicies); err != nil {
+		t.Fatalf("Expected Never to be valid for jobs: %s", err)
+	}
+	if err := validatePodRestartPolicy(api.RestartPolicyAlways, jobRestartPolicies); err == nil {
+		t.Fatal("Expected Always to be invalid for jobs")
+	}
+	if err := validatePodRestartPolicy(api.RestartPolicyOnFailure, controllerRestartPolicies); err == nil {
+		t.Fatal("Expected OnFailure to be invalid for controllers")
+	}
+}
+
+func TestValidatePodSpecReferences(t *testing.T) {
+	cases := []struct {
+		Spec         api.PodSpec
+		ExtraVolumes []string
+		Expected     []string
+	}{
+		{
+			Spec: api.PodSpec{
+				Containers: []api.Container{
+					{
+						Name:         "app",
+						Ports:        []api.ContainerPort{{Name: "http"}, {Name: "metrics"}},
+						VolumeMounts: []api.VolumeMount{{Name: "config"}, {Name: "data"}},
+					},
+				},
+				Volumes: []api.Volume{{Name: "config"}},
+			},
+			ExtraVolumes: []string{"data"},
+			Expected:     []string{},
+		},
+		{
+			Spec: api.PodSpec{
+				InitContainers: []api.Container{{Name: "app"}},
+				Containers: []api.Container{
+					{
+						Name:  "app",
+						Ports: []api.ContainerPort{{Name: "http"}, {Name: "http"}},
+					},
+				},
+				Volumes: []api.Volume{{Name: "config"}, {Name: "config"}},
+			},
+			Expected: []string{
+				`volume.1.name: duplicate volume name "config"`,
+				`container.0.name: duplicate container name "app"`,
+				`container.0.port.1.name: duplicate port name "http" in container "app"`,
+			},
+		},
+		{
+			Spec: api.PodSpec{
+				Containers: []api.Container{
+					{
+						Name:         "app",
+						VolumeMounts: []api.VolumeMount{{Name: "config"}, {Name: "data"}},
+					},
+				},
+				Volumes: []api.Volume{{Name: "config"}},
+			},
+			Expected: []string{
+				`container.0.volume_mount.1.name: volume "data" is not defined`,
+			},
+		},
+		{
+			Spec: api.PodSpec{
+				Containers: []api.Container{
+					{
+						Name:         "app",
+						VolumeMounts: []api.VolumeMount{{Name: "data"}},
+					},
+				},
+				Volumes: []api.Volume{{Name: config.UnknownVariableValue}},
+			},
+			Expected: []string{},
+		},
+	}
+
+	for i, tc := range cases {
+		errs := validatePodSpecReferences(tc.Spec, tc.ExtraVolumes)
+		messages := make([]string, len(errs))
+		for j, err := range errs {
+			messages[j] = err.Error()
+		}
+		if strings.Join(messages, "\n") != strings.Join(tc.Expected, "\n") {
+			t.Fatalf("case %d: expected errors:\n%s\n\ngot:\n%s", i, strings.Join(tc.Expected, "\n"), strings.Join(messages, "\n"))
+		}
+	}
+}